@@ -0,0 +1,7 @@
+data "sql_columns" "users" {
+  table = "users"
+}
+
+output "nullable_columns" {
+  value = [for c in data.sql_columns.users.columns : c.name if c.nullable]
+}
@@ -0,0 +1,7 @@
+data "sql_indexes" "users" {
+  table = "users"
+}
+
+output "unique_indexes" {
+  value = [for i in data.sql_indexes.users.indexes : i.name if i.unique]
+}
@@ -0,0 +1,12 @@
+data "sql_server" "current" {
+}
+
+resource "sql_pg_extension" "pgcrypto" {
+  count = data.sql_server.current.product == "postgres" ? 1 : 0
+
+  name = "pgcrypto"
+}
+
+output "server" {
+  value = "${data.sql_server.current.product} ${data.sql_server.current.major_version}.${data.sql_server.current.minor_version}"
+}
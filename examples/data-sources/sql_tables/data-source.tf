@@ -0,0 +1,12 @@
+data "sql_tables" "reporting" {
+  schema     = "reporting"
+  name_regex = "^daily_"
+}
+
+resource "sql_grant" "reporting" {
+  role        = "analyst"
+  object_type = "table"
+  schema      = "reporting"
+  objects     = data.sql_tables.reporting.tables[*].name
+  privileges  = ["SELECT"]
+}
@@ -0,0 +1 @@
+terraform import sql_database.app app
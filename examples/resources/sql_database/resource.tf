@@ -0,0 +1,5 @@
+resource "sql_database" "app" {
+  name     = "app"
+  owner    = sql_role.app.name
+  encoding = "UTF8"
+}
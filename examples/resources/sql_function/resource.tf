@@ -0,0 +1,6 @@
+resource "sql_function" "add" {
+  name      = "add"
+  arguments = "a integer, b integer"
+  returns   = "integer"
+  body      = "SELECT a + b"
+}
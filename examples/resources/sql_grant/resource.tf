@@ -0,0 +1,14 @@
+resource "sql_grant" "app_tables" {
+  role        = sql_role.app.name
+  object_type = "table"
+  schema      = "public"
+  objects     = [sql_table.users.name]
+  privileges  = ["SELECT", "INSERT", "UPDATE"]
+}
+
+resource "sql_grant" "app_schema" {
+  role        = sql_role.app.name
+  object_type = "schema"
+  objects     = ["public"]
+  privileges  = ["USAGE"]
+}
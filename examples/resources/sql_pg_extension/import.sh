@@ -0,0 +1 @@
+terraform import sql_pg_extension.pgcrypto pgcrypto
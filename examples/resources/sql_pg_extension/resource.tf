@@ -0,0 +1,5 @@
+resource "sql_pg_extension" "pgcrypto" {
+  name    = "pgcrypto"
+  schema  = "public"
+  version = "1.3"
+}
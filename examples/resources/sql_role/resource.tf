@@ -0,0 +1,12 @@
+resource "sql_role" "readers" {
+  name = "readers"
+}
+
+resource "sql_role" "app" {
+  name     = "app"
+  login    = true
+  password = var.app_password
+  roles    = [sql_role.readers.name]
+
+  connection_limit = 10
+}
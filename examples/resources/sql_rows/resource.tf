@@ -0,0 +1,9 @@
+resource "sql_rows" "feature_flags" {
+  table       = "feature_flags"
+  key_columns = ["name"]
+
+  rows = [
+    { name = "new_checkout", enabled = "true" },
+    { name = "dark_mode", enabled = "false" },
+  ]
+}
@@ -0,0 +1 @@
+terraform import sql_schema.reporting reporting
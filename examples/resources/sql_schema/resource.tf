@@ -0,0 +1,4 @@
+resource "sql_schema" "reporting" {
+  name  = "reporting"
+  owner = sql_role.app.name
+}
@@ -0,0 +1,27 @@
+resource "sql_table" "users" {
+  name        = "users"
+  primary_key = ["user_id"]
+
+  column {
+    name = "user_id"
+    type = "serial"
+  }
+
+  column {
+    name     = "email"
+    type     = "varchar(255)"
+    nullable = false
+  }
+
+  column {
+    name    = "created_at"
+    type    = "timestamptz"
+    default = "now()"
+  }
+
+  index {
+    name    = "users_email"
+    columns = ["email"]
+    unique  = true
+  }
+}
@@ -0,0 +1,4 @@
+resource "sql_view" "active_users" {
+  name       = "active_users"
+  definition = "SELECT id, name FROM users WHERE active"
+}
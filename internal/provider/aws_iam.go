@@ -0,0 +1,217 @@
+package provider
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v4"
+)
+
+const (
+	rdsService = "rds-db"
+
+	// rdsTokenExpiry is the maximum lifetime of an RDS auth token, it only
+	// needs to be valid when connecting.
+	rdsTokenExpiry = 15 * time.Minute
+
+	// emptyPayloadHash is the hex SHA-256 of an empty body.
+	emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+)
+
+type awsCredentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+}
+
+// awsCredentialsFromEnv reads the standard AWS credential environment
+// variables.
+func awsCredentialsFromEnv() (awsCredentials, error) {
+	creds := awsCredentials{
+		AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+	}
+
+	if creds.AccessKeyID == "" || creds.SecretAccessKey == "" {
+		return creds, fmt.Errorf("AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY must be set for aws_iam_auth")
+	}
+
+	return creds, nil
+}
+
+// rdsRegion returns the region of an RDS endpoint, ie.
+// db.abc123.us-east-1.rds.amazonaws.com, falling back to the standard AWS
+// region environment variables for custom host names.
+func rdsRegion(host string) (string, error) {
+	labels := strings.Split(host, ".")
+	for i := 1; i < len(labels)-1; i++ {
+		if labels[i] == "rds" && labels[i+1] == "amazonaws" {
+			return labels[i-1], nil
+		}
+	}
+
+	for _, env := range []string{"AWS_REGION", "AWS_DEFAULT_REGION"} {
+		if region := os.Getenv(env); region != "" {
+			return region, nil
+		}
+	}
+
+	return "", fmt.Errorf("unable to determine the AWS region of %q, set AWS_REGION", host)
+}
+
+// rdsIAMPassword returns a password func that generates a new RDS auth token
+// for the user and endpoint of the data source.
+func rdsIAMPassword(ds dataSource) (passwordFunc, error) {
+	var endpoint, user string
+
+	switch ds.driver {
+	case driverPgx:
+		cfg, err := pgx.ParseConfig(ds.url)
+		if err != nil {
+			return nil, err
+		}
+		endpoint = net.JoinHostPort(cfg.Host, strconv.Itoa(int(cfg.Port)))
+		user = cfg.User
+
+	case driverMySQL:
+		cfg, err := mysql.ParseDSN(ds.url)
+		if err != nil {
+			return nil, err
+		}
+		endpoint = cfg.Addr
+		if _, _, err := net.SplitHostPort(endpoint); err != nil {
+			endpoint = net.JoinHostPort(endpoint, "3306")
+		}
+		user = cfg.User
+
+	default:
+		return nil, fmt.Errorf("aws_iam_auth is only supported for postgres and mysql")
+	}
+
+	host, _, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	region, err := rdsRegion(host)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context) (string, error) {
+		creds, err := awsCredentialsFromEnv()
+		if err != nil {
+			return "", err
+		}
+
+		return rdsAuthToken(endpoint, region, user, creds, time.Now()), nil
+	}, nil
+}
+
+// rdsAuthToken builds an RDS IAM auth token, which is a SigV4 presigned
+// connect request for the user, without the scheme.
+func rdsAuthToken(endpoint, region, user string, creds awsCredentials, now time.Time) string {
+	now = now.UTC()
+	date := now.Format("20060102")
+	amzDate := now.Format("20060102T150405Z")
+	scope := strings.Join([]string{date, region, rdsService, "aws4_request"}, "/")
+
+	query := map[string]string{
+		"Action":              "connect",
+		"DBUser":              user,
+		"X-Amz-Algorithm":     "AWS4-HMAC-SHA256",
+		"X-Amz-Credential":    creds.AccessKeyID + "/" + scope,
+		"X-Amz-Date":          amzDate,
+		"X-Amz-Expires":       strconv.Itoa(int(rdsTokenExpiry.Seconds())),
+		"X-Amz-SignedHeaders": "host",
+	}
+	if creds.SessionToken != "" {
+		query["X-Amz-Security-Token"] = creds.SessionToken
+	}
+
+	canonicalQuery := canonicalQueryString(query)
+
+	canonicalRequest := strings.Join([]string{
+		"GET",
+		"/",
+		canonicalQuery,
+		"host:" + endpoint + "\n",
+		"host",
+		emptyPayloadHash,
+	}, "\n")
+
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		hexSHA256(canonicalRequest),
+	}, "\n")
+
+	key := sigV4SigningKey(creds.SecretAccessKey, date, region, rdsService)
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	return endpoint + "?" + canonicalQuery + "&X-Amz-Signature=" + signature
+}
+
+// sigV4SigningKey derives the SigV4 signing key for the date, region and
+// service.
+func sigV4SigningKey(secret, date, region, service string) []byte {
+	key := hmacSHA256([]byte("AWS4"+secret), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, service)
+	return hmacSHA256(key, "aws4_request")
+}
+
+func canonicalQueryString(query map[string]string) string {
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, awsURIEncode(k)+"="+awsURIEncode(query[k]))
+	}
+
+	return strings.Join(pairs, "&")
+}
+
+// awsURIEncode escapes everything but the RFC 3986 unreserved characters, as
+// required by SigV4, unlike url.QueryEscape which uses + for spaces.
+func awsURIEncode(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9',
+			c == '-', c == '_', c == '.', c == '~':
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+func hexSHA256(data string) string {
+	sum := sha256.Sum256([]byte(data))
+	return hex.EncodeToString(sum[:])
+}
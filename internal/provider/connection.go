@@ -0,0 +1,214 @@
+package provider
+
+import (
+	"math/big"
+	"net"
+	"net/url"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// connectionConfig is the structured alternative to a connection URL, the
+// values are escaped as needed when assembled in to a URL.
+type connectionConfig struct {
+	Driver   string
+	Host     string
+	Port     int64
+	Database string
+	Username string
+	Password string
+	Params   map[string]string
+}
+
+func connectionBlock() *tfprotov6.SchemaNestedBlock {
+	return &tfprotov6.SchemaNestedBlock{
+		TypeName: "connection",
+		Nesting:  tfprotov6.SchemaNestedBlockNestingModeSingle,
+		Block: &tfprotov6.SchemaBlock{
+			Description: "The connection settings as an alternative to `url`, values in this block do not need to be " +
+				"escaped.",
+			DescriptionKind: tfprotov6.StringKindMarkdown,
+			Attributes: []*tfprotov6.SchemaAttribute{
+				{
+					Name:            "driver",
+					Required:        true,
+					Description:     "The type of database, one of `postgres`, `mysql`, `sqlserver` or `azuresql`.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.String,
+				},
+				{
+					Name:            "host",
+					Required:        true,
+					Description:     "The host name or IP address of the database server.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.String,
+				},
+				{
+					Name:            "port",
+					Optional:        true,
+					Description:     "The port of the database server, the driver's default is used if not set.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.Number,
+				},
+				{
+					Name:            "database",
+					Optional:        true,
+					Description:     "The name of the database to connect to.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.String,
+				},
+				{
+					Name:            "username",
+					Optional:        true,
+					Description:     "The user to connect as.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.String,
+				},
+				{
+					Name:            "password",
+					Optional:        true,
+					Sensitive:       true,
+					Description:     "The password of the user, requires `username`.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.String,
+				},
+				{
+					Name:     "params",
+					Optional: true,
+					Description: "Additional driver specific connection parameters, these are the parameters that would " +
+						"otherwise be in the query string of the URL.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type: tftypes.Map{
+						ElementType: tftypes.String,
+					},
+				},
+			},
+		},
+	}
+}
+
+func connectionFromValue(v tftypes.Value) (connectionConfig, error) {
+	var c connectionConfig
+
+	var values map[string]tftypes.Value
+	err := v.As(&values)
+	if err != nil {
+		return c, err
+	}
+
+	for name, target := range map[string]*string{
+		"driver":   &c.Driver,
+		"host":     &c.Host,
+		"database": &c.Database,
+		"username": &c.Username,
+		"password": &c.Password,
+	} {
+		err = values[name].As(target)
+		if err != nil {
+			return c, attributeErrorf(name, "unable to read %s: %w", name, err)
+		}
+	}
+	if c.Password != "" && c.Username == "" {
+		return c, attributeErrorf("password", "password cannot be set without a username")
+	}
+
+	if port := values["port"]; !port.IsNull() {
+		portBig := &big.Float{}
+		err = port.As(&portBig)
+		if err != nil {
+			return c, attributeErrorf("port", "unable to read port: %w", err)
+		}
+
+		var acc big.Accuracy
+		c.Port, acc = portBig.Int64()
+		if acc != big.Exact || c.Port < 1 || c.Port > 65535 {
+			return c, attributeErrorf("port", "port must be an integer between 1 and 65535")
+		}
+	}
+
+	if params := values["params"]; !params.IsNull() {
+		var paramValues map[string]tftypes.Value
+		err = params.As(&paramValues)
+		if err != nil {
+			return c, attributeErrorf("params", "unable to read params: %w", err)
+		}
+
+		c.Params = map[string]string{}
+		for k, pv := range paramValues {
+			var s string
+			err = pv.As(&s)
+			if err != nil {
+				return c, attributeErrorf("params", "unable to read params: %w", err)
+			}
+			c.Params[k] = s
+		}
+	}
+
+	return c, nil
+}
+
+// connectionURLValue assembles the connection block in to a url value, which
+// is unknown if any of the connection settings are not yet known.
+func connectionURLValue(v tftypes.Value) (tftypes.Value, error) {
+	if !v.IsFullyKnown() {
+		return tftypes.NewValue(tftypes.String, tftypes.UnknownValue), nil
+	}
+
+	c, err := connectionFromValue(v)
+	if err != nil {
+		return tftypes.Value{}, err
+	}
+
+	u, err := c.URL()
+	if err != nil {
+		return tftypes.Value{}, err
+	}
+
+	return tftypes.NewValue(tftypes.String, u), nil
+}
+
+// URL assembles the connection settings in to a URL in the same form as the
+// url attribute, so it can be parsed with parseUrl.
+func (c connectionConfig) URL() (string, error) {
+	if c.Host == "" {
+		return "", attributeErrorf("host", "host can't be empty")
+	}
+
+	d := dialectForScheme(c.Driver)
+	if d == nil {
+		return "", attributeErrorf("driver", "unsupported driver: %q", c.Driver)
+	}
+
+	return d.ConnectionURL(c)
+}
+
+// hostPort returns the host, with the port if set.
+func (c connectionConfig) hostPort() string {
+	if c.Port == 0 {
+		return c.Host
+	}
+	return net.JoinHostPort(c.Host, strconv.FormatInt(c.Port, 10))
+}
+
+// userinfo returns the user and password for a URL, or nil if not set.
+func (c connectionConfig) userinfo() *url.Userinfo {
+	switch {
+	case c.Username != "" && c.Password != "":
+		return url.UserPassword(c.Username, c.Password)
+	case c.Username != "":
+		return url.User(c.Username)
+	default:
+		return nil
+	}
+}
+
+// query returns the params as URL query values.
+func (c connectionConfig) query() url.Values {
+	query := url.Values{}
+	for k, v := range c.Params {
+		query.Set(k, v)
+	}
+	return query
+}
@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"crypto/tls"
+	"database/sql"
+	"fmt"
+)
+
+// connectorOptions are the connection settings that cannot be expressed in a
+// url, and so require building a driver.Connector.
+type connectorOptions struct {
+	TLS    *tls.Config
+	Dialer contextDialer
+
+	// InitStatements are run on each new connection.
+	InitStatements []string
+
+	// Password is called for each new connection, overriding the password of
+	// the url.
+	Password passwordFunc
+
+	// CleartextPassword allows sending the password in clear text, which
+	// mysql requires for IAM auth tokens. TLS should be used with it.
+	CleartextPassword bool
+}
+
+func (o connectorOptions) isZero() bool {
+	return o.TLS == nil && o.Dialer == nil && len(o.InitStatements) == 0 && o.Password == nil
+}
+
+// openDB opens the database of the data source, using a driver.Connector when
+// any options are set.
+func openDB(ds dataSource, opts connectorOptions) (*sql.DB, error) {
+	if opts.isZero() {
+		return sql.Open(string(ds.driver), ds.url)
+	}
+
+	d := ds.dialect()
+	if d == nil {
+		return nil, fmt.Errorf("connection options are not supported for the %s driver", ds.driver)
+	}
+
+	c, err := d.Connector(ds.url, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return sql.OpenDB(c), nil
+}
+
+// withServerName returns a copy of the TLS config, with the server name
+// defaulted to host if not already set.
+func withServerName(cfg *tls.Config, host string) *tls.Config {
+	cfg = cfg.Clone()
+	if cfg.ServerName == "" {
+		cfg.ServerName = host
+	}
+	return cfg
+}
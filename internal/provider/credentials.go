@@ -0,0 +1,149 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// passwordFunc returns the password for a new connection.
+type passwordFunc func(ctx context.Context) (string, error)
+
+func passwordAttributes() []*tfprotov6.SchemaAttribute {
+	return []*tfprotov6.SchemaAttribute{
+		{
+			Name:     "password_command",
+			Optional: true,
+			Description: "A command and its arguments that prints the password, ie. a short-lived token, which is run " +
+				"each time a new connection is opened and replaces any password in the URL. For SQL Server without a " +
+				"user, and for `azuresql`, the output is used as an Azure AD access token instead.",
+			DescriptionKind: tfprotov6.StringKindMarkdown,
+			Type: tftypes.List{
+				ElementType: tftypes.String,
+			},
+		},
+		{
+			Name:     "password_file",
+			Optional: true,
+			Description: "The path of a file containing the password, which is read each time a new connection is " +
+				"opened, so it can be rotated by an external agent. This is used in the same way as `password_command`.",
+			DescriptionKind: tfprotov6.StringKindMarkdown,
+			Type:            tftypes.String,
+		},
+	}
+}
+
+// passwordFromConfig returns the password func of the password_command or
+// password_file attributes, or nil if neither is set.
+func passwordFromConfig(config map[string]tftypes.Value) (passwordFunc, error) {
+	command, file := config["password_command"], config["password_file"]
+
+	switch {
+	case !command.IsNull() && !file.IsNull():
+		return nil, attributeErrorf("password_file", "only one of password_command or password_file can be set")
+
+	case !command.IsNull():
+		var values []tftypes.Value
+		err := command.As(&values)
+		if err != nil {
+			return nil, attributeErrorf("password_command", "unable to read password_command: %w", err)
+		}
+		if len(values) == 0 {
+			return nil, attributeErrorf("password_command", "password_command can't be empty")
+		}
+
+		args := make([]string, 0, len(values))
+		for _, v := range values {
+			var arg string
+			err = v.As(&arg)
+			if err != nil {
+				return nil, attributeErrorf("password_command", "unable to read password_command: %w", err)
+			}
+			args = append(args, arg)
+		}
+
+		return commandPassword(args), nil
+
+	case !file.IsNull():
+		var path string
+		err := file.As(&path)
+		if err != nil {
+			return nil, attributeErrorf("password_file", "unable to read password_file: %w", err)
+		}
+		if path == "" {
+			return nil, attributeErrorf("password_file", "password_file can't be empty")
+		}
+
+		return filePassword(path), nil
+	}
+
+	return nil, nil
+}
+
+func commandPassword(args []string) passwordFunc {
+	return func(ctx context.Context) (string, error) {
+		var stderr bytes.Buffer
+		cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+		cmd.Stderr = &stderr
+
+		out, err := cmd.Output()
+		if err != nil {
+			if msg := strings.TrimSpace(stderr.String()); msg != "" {
+				return "", fmt.Errorf("password_command failed: %w: %s", err, msg)
+			}
+			return "", fmt.Errorf("password_command failed: %w", err)
+		}
+
+		return trimPassword(out), nil
+	}
+}
+
+func filePassword(path string) passwordFunc {
+	return func(ctx context.Context) (string, error) {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("unable to read password_file: %w", err)
+		}
+
+		return trimPassword(b), nil
+	}
+}
+
+// trimPassword removes the trailing newline that most commands and editors
+// add, other whitespace is kept as it may be part of the password.
+func trimPassword(b []byte) string {
+	return strings.TrimRight(string(b), "\r\n")
+}
+
+// passwordConnector fetches the password and builds a new connector for each
+// connection, for drivers without a hook to change the password.
+type passwordConnector struct {
+	driver    driver.Driver
+	password  passwordFunc
+	connector func(password string) (driver.Connector, error)
+}
+
+func (c *passwordConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	password, err := c.password(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	connector, err := c.connector(password)
+	if err != nil {
+		return nil, err
+	}
+
+	return connector.Connect(ctx)
+}
+
+func (c *passwordConnector) Driver() driver.Driver {
+	return c.driver
+}
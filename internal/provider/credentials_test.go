@@ -0,0 +1,124 @@
+package provider
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestPasswordFromConfig(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test commands require a posix shell")
+	}
+
+	path := filepath.Join(t.TempDir(), "password")
+
+	commands := tftypes.List{ElementType: tftypes.String}
+	command := func(args ...string) tftypes.Value {
+		values := []tftypes.Value{}
+		for _, arg := range args {
+			values = append(values, tftypes.NewValue(tftypes.String, arg))
+		}
+		return tftypes.NewValue(commands, values)
+	}
+
+	for name, c := range map[string]struct {
+		command  tftypes.Value
+		file     tftypes.Value
+		contents string
+		expected string
+	}{
+		"command": {
+			command:  command("sh", "-c", "echo 's3cret '"),
+			file:     tftypes.NewValue(tftypes.String, nil),
+			expected: "s3cret ",
+		},
+		"file": {
+			command:  tftypes.NewValue(commands, nil),
+			file:     tftypes.NewValue(tftypes.String, path),
+			contents: "s3cret\r\n",
+			expected: "s3cret",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := os.WriteFile(path, []byte(c.contents), 0600)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			password, err := passwordFromConfig(map[string]tftypes.Value{
+				"password_command": c.command,
+				"password_file":    c.file,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			actual, err := password(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if actual != c.expected {
+				t.Fatalf("expected %q, got %q", c.expected, actual)
+			}
+		})
+	}
+
+	t.Run("command failure", func(t *testing.T) {
+		password, err := passwordFromConfig(map[string]tftypes.Value{
+			"password_command": command("sh", "-c", "echo 'token expired' >&2; exit 1"),
+			"password_file":    tftypes.NewValue(tftypes.String, nil),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = password(context.Background())
+		if err == nil {
+			t.Fatal("expected error")
+		}
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		_, err := passwordFromConfig(map[string]tftypes.Value{
+			"password_command": command("true"),
+			"password_file":    tftypes.NewValue(tftypes.String, path),
+		})
+		if err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
+
+func TestPasswordConnector_Connect(t *testing.T) {
+	var passwords []string
+	next := 0
+
+	c := &passwordConnector{
+		password: func(ctx context.Context) (string, error) {
+			next++
+			return fmt.Sprintf("token%d", next), nil
+		},
+		connector: func(password string) (driver.Connector, error) {
+			passwords = append(passwords, password)
+			return &fakeConnector{&fakeConn{}}, nil
+		},
+	}
+
+	for i := 0; i < 2; i++ {
+		_, err := c.Connect(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(passwords) != 2 || passwords[0] != "token1" || passwords[1] != "token2" {
+		t.Fatalf("expected the password to be fetched for each connection, got %v", passwords)
+	}
+}
@@ -0,0 +1,122 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/ialexj/terraform-provider-sql/internal/server"
+)
+
+var introspectedColumnType = tftypes.Object{
+	AttributeTypes: map[string]tftypes.Type{
+		"name":     tftypes.String,
+		"type":     tftypes.String,
+		"nullable": tftypes.Bool,
+		"default":  tftypes.String,
+	},
+}
+
+type dataColumns struct {
+	db dbConnector
+}
+
+var _ server.DataSource = (*dataColumns)(nil)
+
+func newDataColumns(db dbConnector) (*dataColumns, error) {
+	return &dataColumns{
+		db: db,
+	}, nil
+}
+
+func (d *dataColumns) Schema(context.Context) *tfprotov6.Schema {
+	return &tfprotov6.Schema{
+		Block: &tfprotov6.SchemaBlock{
+			Description:     "The `sql_columns` datasource lists the columns of a table.",
+			DescriptionKind: tfprotov6.StringKindMarkdown,
+			Attributes: []*tfprotov6.SchemaAttribute{
+				urlAttribute(),
+				initStatementsAttribute("SQL statements to run when connecting for these columns, after those of the provider."),
+				{
+					Name:            "schema",
+					Optional:        true,
+					Description:     "The schema of the table, defaults to the current schema.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.String,
+				},
+				{
+					Name:            "table",
+					Required:        true,
+					Description:     "The name of the table.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.String,
+				},
+				nameRegexAttribute("columns"),
+				{
+					Name:     "columns",
+					Computed: true,
+					Description: "The columns in table order, empty if the table does not exist. The `type` is spelled " +
+						"as in `sql_table`, and `default` is the SQL expression of the default value, if any.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.List{ElementType: introspectedColumnType},
+				},
+
+				deprecatedIDAttribute(),
+			},
+		},
+	}
+}
+
+func (d *dataColumns) Validate(ctx context.Context, config map[string]tftypes.Value) ([]*tfprotov6.Diagnostic, error) {
+	return validateNameRegex(config)
+}
+
+func (d *dataColumns) Read(ctx context.Context, config map[string]tftypes.Value) (map[string]tftypes.Value, []*tfprotov6.Diagnostic, error) {
+	dialect, db, schema, err := connectIntrospection(ctx, d.db, config)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	nameRegex, err := nameRegexFromValue(config["name_regex"])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var name string
+	err = config["table"].As(&name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tableColumns, err := dialect.ReadColumns(ctx, db, schema, name)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to read columns of %q: %w", name, err)
+	}
+
+	columns := []tftypes.Value{}
+	for _, c := range tableColumns {
+		if nameRegex != nil && !nameRegex.MatchString(c.Name) {
+			continue
+		}
+		columns = append(columns, tftypes.NewValue(introspectedColumnType, map[string]tftypes.Value{
+			"name":     tftypes.NewValue(tftypes.String, c.Name),
+			"type":     tftypes.NewValue(tftypes.String, c.Type),
+			"nullable": tftypes.NewValue(tftypes.Bool, c.Nullable),
+			"default":  tftypes.NewValue(tftypes.String, c.Default),
+		}))
+	}
+
+	return map[string]tftypes.Value{
+		"url":             config["url"],
+		"init_statements": config["init_statements"],
+		"schema":          config["schema"],
+		"table":           config["table"],
+		"name_regex":      config["name_regex"],
+		"columns":         tftypes.NewValue(tftypes.List{ElementType: introspectedColumnType}, columns),
+
+		// just a placeholder, see deprecatedIDAttribute
+		"id": tftypes.NewValue(tftypes.String, ""),
+	}, nil, nil
+}
@@ -27,14 +27,7 @@ func (d *dataDriver) Schema(context.Context) *tfprotov6.Schema {
 				"is mostly useful for module development when you may communicate with multiple types of databases.",
 			DescriptionKind: tfprotov6.StringKindMarkdown,
 			Attributes: []*tfprotov6.SchemaAttribute{
-				{
-					Name:            "url",
-					Optional:        true,
-					Computed:        true,
-					Description:     "The database URL to connect to.",
-					DescriptionKind: tfprotov6.StringKindMarkdown,
-					Type:            tftypes.String,
-				},
+				urlAttribute(),
 				{
 					Name:            "name",
 					Computed:        true,
@@ -45,7 +38,7 @@ func (d *dataDriver) Schema(context.Context) *tfprotov6.Schema {
 				{
 					Name:            "datasource",
 					Computed:        true,
-					Description:     "The URL that's passed to the underlying connection.",
+					Description:     "The URL that's passed to the underlying connection, with any credentials redacted.",
 					DescriptionKind: tfprotov6.StringKindMarkdown,
 					Type:            tftypes.String,
 				},
@@ -72,7 +65,7 @@ func (d *dataDriver) Read(ctx context.Context, config map[string]tftypes.Value)
 			return nil, nil, err
 		}
 		name = tftypes.NewValue(tftypes.String, string(ds.driver))
-		url = tftypes.NewValue(tftypes.String, ds.url)
+		url = tftypes.NewValue(tftypes.String, ds.redactedURL())
 	}
 
 	return map[string]tftypes.Value{
@@ -43,6 +43,14 @@ data "sql_driver" "test" {
 								if att != server.ExpectedDriver {
 									return fmt.Errorf("expected %q, got %q", server.ExpectedDriver, att)
 								}
+
+								ds, err := parseUrl(url)
+								if err != nil {
+									return err
+								}
+								if att := rs.Primary.Attributes["datasource"]; att != ds.redactedURL() {
+									return fmt.Errorf("expected redacted datasource %q, got %q", ds.redactedURL(), att)
+								}
 								return nil
 							},
 						),
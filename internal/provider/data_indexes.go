@@ -0,0 +1,124 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/ialexj/terraform-provider-sql/internal/server"
+)
+
+var introspectedIndexType = tftypes.Object{
+	AttributeTypes: map[string]tftypes.Type{
+		"name":    tftypes.String,
+		"columns": tftypes.List{ElementType: tftypes.String},
+		"unique":  tftypes.Bool,
+	},
+}
+
+type dataIndexes struct {
+	db dbConnector
+}
+
+var _ server.DataSource = (*dataIndexes)(nil)
+
+func newDataIndexes(db dbConnector) (*dataIndexes, error) {
+	return &dataIndexes{
+		db: db,
+	}, nil
+}
+
+func (d *dataIndexes) Schema(context.Context) *tfprotov6.Schema {
+	return &tfprotov6.Schema{
+		Block: &tfprotov6.SchemaBlock{
+			Description:     "The `sql_indexes` datasource lists the indexes of a table.",
+			DescriptionKind: tfprotov6.StringKindMarkdown,
+			Attributes: []*tfprotov6.SchemaAttribute{
+				urlAttribute(),
+				initStatementsAttribute("SQL statements to run when connecting for these indexes, after those of the provider."),
+				{
+					Name:            "schema",
+					Optional:        true,
+					Description:     "The schema of the table, defaults to the current schema.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.String,
+				},
+				{
+					Name:            "table",
+					Required:        true,
+					Description:     "The name of the table.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.String,
+				},
+				nameRegexAttribute("indexes"),
+				{
+					Name:     "indexes",
+					Computed: true,
+					Description: "The indexes sorted by name, with their columns in index order. The primary key and " +
+						"indexes backing constraints are not included.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.List{ElementType: introspectedIndexType},
+				},
+
+				deprecatedIDAttribute(),
+			},
+		},
+	}
+}
+
+func (d *dataIndexes) Validate(ctx context.Context, config map[string]tftypes.Value) ([]*tfprotov6.Diagnostic, error) {
+	return validateNameRegex(config)
+}
+
+func (d *dataIndexes) Read(ctx context.Context, config map[string]tftypes.Value) (map[string]tftypes.Value, []*tfprotov6.Diagnostic, error) {
+	dialect, db, schema, err := connectIntrospection(ctx, d.db, config)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	nameRegex, err := nameRegexFromValue(config["name_regex"])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var name string
+	err = config["table"].As(&name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tableIndexes, err := dialect.ReadIndexes(ctx, db, schema, name)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to read indexes of %q: %w", name, err)
+	}
+
+	indexes := []tftypes.Value{}
+	for _, index := range tableIndexes {
+		if nameRegex != nil && !nameRegex.MatchString(index.Name) {
+			continue
+		}
+		columns := make([]tftypes.Value, len(index.Columns))
+		for i, c := range index.Columns {
+			columns[i] = tftypes.NewValue(tftypes.String, c)
+		}
+		indexes = append(indexes, tftypes.NewValue(introspectedIndexType, map[string]tftypes.Value{
+			"name":    tftypes.NewValue(tftypes.String, index.Name),
+			"columns": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, columns),
+			"unique":  tftypes.NewValue(tftypes.Bool, index.Unique),
+		}))
+	}
+
+	return map[string]tftypes.Value{
+		"url":             config["url"],
+		"init_statements": config["init_statements"],
+		"schema":          config["schema"],
+		"table":           config["table"],
+		"name_regex":      config["name_regex"],
+		"indexes":         tftypes.NewValue(tftypes.List{ElementType: introspectedIndexType}, indexes),
+
+		// just a placeholder, see deprecatedIDAttribute
+		"id": tftypes.NewValue(tftypes.String, ""),
+	}, nil, nil
+}
@@ -2,9 +2,12 @@ package provider
 
 import (
 	"fmt"
+	"path/filepath"
+	"regexp"
 	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	helperresource "github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
@@ -236,3 +239,435 @@ output "query" {
 		})
 	}
 }
+
+func TestDataQuery_resultShapes(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping long test")
+	}
+
+	for _, server := range testServers {
+		t.Run(server.ServerType, func(t *testing.T) {
+			url, _, err := server.URL()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			helperresource.UnitTest(t, helperresource.TestCase{
+				ProtoV6ProviderFactories: protoV6ProviderFactories,
+				Steps: []helperresource.TestStep{
+					{
+						Config: fmt.Sprintf(`
+provider "sql" {
+	url = %q
+
+	max_idle_conns = 0
+}
+
+data "sql_query" "map" {
+	query      = "select 'a' as code, 1 as num union all select 'b' as code, 2 as num"
+	key_column = "code"
+}
+
+data "sql_query" "single" {
+	query      = "select 'a' as code, 1 as num"
+	single_row = true
+}
+
+data "sql_query" "scalar" {
+	query = "select 42 as answer"
+}
+
+output "map_b" {
+	value = data.sql_query.map.result_map["b"].num
+}
+
+output "row_code" {
+	value = data.sql_query.single.row.code
+}
+
+output "scalar" {
+	value = data.sql_query.scalar.scalar
+}
+				`, url),
+						Check: helperresource.ComposeTestCheckFunc(
+							helperresource.TestCheckOutput("map_b", "2"),
+							helperresource.TestCheckOutput("row_code", "a"),
+							helperresource.TestCheckOutput("scalar", "42"),
+						),
+					},
+					{
+						Config: fmt.Sprintf(`
+provider "sql" {
+	url = %q
+
+	max_idle_conns = 0
+}
+
+data "sql_query" "single" {
+	query      = "select 'a' as code union all select 'b' as code"
+	single_row = true
+}
+				`, url),
+						ExpectError: regexp.MustCompile(`exactly one row, got 2`),
+					},
+					{
+						Config: fmt.Sprintf(`
+provider "sql" {
+	url = %q
+
+	max_idle_conns = 0
+}
+
+data "sql_query" "map" {
+	query      = "select 'a' as code union all select 'a' as code"
+	key_column = "code"
+}
+				`, url),
+						ExpectError: regexp.MustCompile(`duplicate key "a"`),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestDataQuery_multipleResultSets(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping long test")
+	}
+
+	for _, server := range testServers {
+		t.Run(server.ServerType, func(t *testing.T) {
+			url, scheme, err := server.URL()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			switch scheme {
+			case "mysql":
+				url += "&multiStatements=true"
+			case "sqlserver":
+			default:
+				t.Skipf("multiple result sets are not supported for %s", server.ServerType)
+			}
+
+			helperresource.UnitTest(t, helperresource.TestCase{
+				ProtoV6ProviderFactories: protoV6ProviderFactories,
+				Steps: []helperresource.TestStep{
+					{
+						Config: fmt.Sprintf(`
+provider "sql" {
+	url = %q
+
+	max_idle_conns = 0
+}
+
+data "sql_query" "test" {
+	query = "select 1 as a; select 'x' as b, 2 as c union all select 'y' as b, 3 as c;"
+}
+
+output "count" {
+	value = length(data.sql_query.test.results)
+}
+
+output "first" {
+	value = data.sql_query.test.result[0].a
+}
+
+output "second" {
+	value = data.sql_query.test.results[1][1].b
+}
+				`, url),
+						Check: helperresource.ComposeTestCheckFunc(
+							helperresource.TestCheckOutput("count", "2"),
+							helperresource.TestCheckOutput("first", "1"),
+							helperresource.TestCheckOutput("second", "y"),
+						),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestDataQuery_limits(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping long test")
+	}
+
+	const query = "select 'a' as code union all select 'b' as code union all select 'c' as code"
+
+	for _, server := range testServers {
+		t.Run(server.ServerType, func(t *testing.T) {
+			url, _, err := server.URL()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			helperresource.UnitTest(t, helperresource.TestCase{
+				ProtoV6ProviderFactories: protoV6ProviderFactories,
+				Steps: []helperresource.TestStep{
+					{
+						Config: fmt.Sprintf(`
+provider "sql" {
+	url = %q
+
+	max_idle_conns = 0
+}
+
+data "sql_query" "test" {
+	query    = %q
+	max_rows = 2
+}
+				`, url, query),
+						ExpectError: regexp.MustCompile(`more than max_rows \(2\) rows`),
+					},
+					{
+						Config: fmt.Sprintf(`
+provider "sql" {
+	url = %q
+
+	max_idle_conns = 0
+}
+
+data "sql_query" "test" {
+	query    = %q
+	max_rows = 2
+	truncate = true
+}
+
+output "rowcount" {
+	value = length(data.sql_query.test.result)
+}
+				`, url, query),
+						Check: helperresource.TestCheckOutput("rowcount", "2"),
+					},
+					{
+						Config: fmt.Sprintf(`
+provider "sql" {
+	url = %q
+
+	max_idle_conns   = 0
+	max_result_bytes = 10
+}
+
+data "sql_query" "test" {
+	query = %q
+}
+				`, url, query),
+						ExpectError: regexp.MustCompile(`exceeded max_result_bytes`),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestResultLimitsFromConfig(t *testing.T) {
+	for name, c := range map[string]struct {
+		maxRows  tftypes.Value
+		expected int64
+	}{
+		"unset": {tftypes.NewValue(tftypes.Number, nil), 0},
+		"set":   {tftypes.NewValue(tftypes.Number, 2), 2},
+	} {
+		t.Run(name, func(t *testing.T) {
+			limits, err := resultLimitsFromConfig(map[string]tftypes.Value{
+				"max_rows": c.maxRows,
+				"truncate": tftypes.NewValue(tftypes.Bool, nil),
+			}, 10)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if limits.maxRows != c.expected || limits.maxBytes != 10 || limits.truncate {
+				t.Fatalf("unexpected limits %+v", limits)
+			}
+		})
+	}
+}
+
+func TestDataQuery_readOnly(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping long test")
+	}
+
+	for _, server := range testServers {
+		t.Run(server.ServerType, func(t *testing.T) {
+			url, scheme, err := server.URL()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			config := func(allowWrites bool) string {
+				return fmt.Sprintf(`
+provider "sql" {
+	url = %q
+
+	max_idle_conns = 0
+}
+
+resource "sql_migrate" "db" {
+	migration {
+		id   = "create table"
+		up   = "CREATE TABLE read_only_test (id integer)"
+		down = "DROP TABLE read_only_test"
+	}
+}
+
+data "sql_query" "write" {
+	depends_on = [sql_migrate.db]
+
+	query        = "INSERT INTO read_only_test VALUES (1)"
+	allow_writes = %t
+}
+
+data "sql_query" "count" {
+	depends_on = [data.sql_query.write]
+
+	query = "SELECT count(*) AS n FROM read_only_test"
+}
+
+output "count" {
+	value = data.sql_query.count.scalar
+}
+				`, url, allowWrites)
+			}
+
+			readOnlyStep := helperresource.TestStep{
+				Config: config(false),
+				Check:  helperresource.TestCheckOutput("count", "0"),
+			}
+			if scheme != "sqlserver" {
+				// sql server has no read only transactions, so the write is
+				// only rolled back, other drivers should reject it
+				readOnlyStep.Check = nil
+				readOnlyStep.ExpectError = regexp.MustCompile(`(?i)read.only`)
+			}
+
+			helperresource.UnitTest(t, helperresource.TestCase{
+				ProtoV6ProviderFactories: protoV6ProviderFactories,
+				Steps: []helperresource.TestStep{
+					readOnlyStep,
+					{
+						Config: config(true),
+						Check:  helperresource.TestCheckOutput("count", "1"),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestDataQuery_queryFile(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping long test")
+	}
+
+	queryFile, err := filepath.Abs(filepath.Join("testdata", "query.sql"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, server := range testServers {
+		t.Run(server.ServerType, func(t *testing.T) {
+			url, _, err := server.URL()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			helperresource.UnitTest(t, helperresource.TestCase{
+				ProtoV6ProviderFactories: protoV6ProviderFactories,
+				Steps: []helperresource.TestStep{
+					{
+						Config: fmt.Sprintf(`
+provider "sql" {
+	url = %q
+
+	max_idle_conns = 0
+}
+
+data "sql_query" "test" {
+	query_file = %q
+
+	vars = {
+		greeting = "it's a test"
+		column   = "my column"
+	}
+}
+
+output "greeting" {
+	value = data.sql_query.test.result[0]["my column"]
+}
+				`, url, queryFile),
+						Check: helperresource.ComposeTestCheckFunc(
+							helperresource.TestCheckOutput("greeting", "it's a test"),
+							helperresource.TestCheckResourceAttr("data.sql_query.test", "id", queryFile),
+						),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestDataQuery_initStatements(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping long test")
+	}
+
+	for _, server := range testServers {
+		t.Run(server.ServerType, func(t *testing.T) {
+			url, scheme, err := server.URL()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// the data source statements run after the provider's, so its
+			// value should win
+			var providerStmt, queryStmt, query, expected string
+			switch scheme {
+			case "mysql":
+				providerStmt = "SET @@session.time_zone = '+01:00'"
+				queryStmt = "SET @@session.time_zone = '+02:00'"
+				query = "SELECT CAST(@@session.time_zone AS CHAR) AS v"
+				expected = "+02:00"
+			case "sqlserver":
+				providerStmt = "SET LANGUAGE British"
+				queryStmt = "SET LANGUAGE Deutsch"
+				query = "SELECT @@LANGUAGE AS v"
+				expected = "Deutsch"
+			default:
+				providerStmt = "SET application_name = 'provider'"
+				queryStmt = "SET application_name = 'query'"
+				query = "SELECT current_setting('application_name') AS v"
+				expected = "query"
+			}
+
+			helperresource.UnitTest(t, helperresource.TestCase{
+				ProtoV6ProviderFactories: protoV6ProviderFactories,
+				Steps: []helperresource.TestStep{
+					{
+						Config: fmt.Sprintf(`
+provider "sql" {
+	url = %q
+
+	max_idle_conns  = 0
+	init_statements = [%q]
+}
+
+data "sql_query" "test" {
+	query           = %q
+	init_statements = [%q]
+}
+
+output "value" {
+	value = data.sql_query.test.scalar
+}
+				`, url, providerStmt, query, queryStmt),
+						Check: helperresource.TestCheckOutput("value", expected),
+					},
+				},
+			})
+		})
+	}
+}
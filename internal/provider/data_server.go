@@ -0,0 +1,143 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/ialexj/terraform-provider-sql/internal/server"
+)
+
+type dataServer struct {
+	db dbConnector
+}
+
+var _ server.DataSource = (*dataServer)(nil)
+
+func newDataServer(db dbConnector) (*dataServer, error) {
+	return &dataServer{
+		db: db,
+	}, nil
+}
+
+func (d *dataServer) Schema(context.Context) *tfprotov6.Schema {
+	return &tfprotov6.Schema{
+		Block: &tfprotov6.SchemaBlock{
+			Description: "The `sql_server` datasource describes the server the provider connects to, so that modules " +
+				"can depend on the features of the server rather than the driver, which is shared by for example " +
+				"PostgreSQL and CockroachDB.",
+			DescriptionKind: tfprotov6.StringKindMarkdown,
+			Attributes: []*tfprotov6.SchemaAttribute{
+				urlAttribute(),
+				initStatementsAttribute("SQL statements to run when connecting for this server, after those of the provider."),
+				{
+					Name:     "product",
+					Computed: true,
+					Description: "The server product, one of `postgres`, `cockroachdb`, `mysql`, `mariadb`, `sqlserver` " +
+						"or `azuresql`.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.String,
+				},
+				{
+					Name:            "version",
+					Computed:        true,
+					Description:     "The version string reported by the server, ie. by `SELECT version()`.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.String,
+				},
+				{
+					Name:            "major_version",
+					Computed:        true,
+					Description:     "The major version of the product, ie. `16` for PostgreSQL 16.2.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.Number,
+				},
+				{
+					Name:            "minor_version",
+					Computed:        true,
+					Description:     "The minor version of the product, ie. `2` for PostgreSQL 16.2.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.Number,
+				},
+				{
+					Name:            "database",
+					Computed:        true,
+					Description:     "The current database, `null` if the connection has none.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.String,
+				},
+				{
+					Name:            "user",
+					Computed:        true,
+					Description:     "The user or login the connection is authenticated as.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.String,
+				},
+				{
+					Name:     "transactional_ddl",
+					Computed: true,
+					Description: "Whether DDL statements can be rolled back as part of a transaction. MySQL and MariaDB " +
+						"commit implicitly, and CockroachDB runs schema changes asynchronously.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.Bool,
+				},
+				{
+					Name:            "advisory_locks",
+					Computed:        true,
+					Description:     "Whether named session locks are supported, ie. `pg_advisory_lock`, `GET_LOCK` or `sp_getapplock`.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.Bool,
+				},
+				{
+					Name:            "if_not_exists",
+					Computed:        true,
+					Description:     "Whether `CREATE TABLE` supports `IF NOT EXISTS`.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.Bool,
+				},
+
+				deprecatedIDAttribute(),
+			},
+		},
+	}
+}
+
+func (d *dataServer) Validate(ctx context.Context, config map[string]tftypes.Value) ([]*tfprotov6.Diagnostic, error) {
+	return nil, nil
+}
+
+func (d *dataServer) Read(ctx context.Context, config map[string]tftypes.Value) (map[string]tftypes.Value, []*tfprotov6.Diagnostic, error) {
+	ds, db, err := d.db.GetQueryer(ctx, config)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dialect, ok := ds.dialect().(serverInfoDialect)
+	if !ok {
+		return nil, nil, fmt.Errorf("server info is not supported for the %s driver", ds.driver)
+	}
+
+	info, err := dialect.ReadServerInfo(ctx, db)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to read server info: %w", err)
+	}
+
+	return map[string]tftypes.Value{
+		"url":               config["url"],
+		"init_statements":   config["init_statements"],
+		"product":           tftypes.NewValue(tftypes.String, info.Product),
+		"version":           tftypes.NewValue(tftypes.String, info.Version),
+		"major_version":     tftypes.NewValue(tftypes.Number, info.Major),
+		"minor_version":     tftypes.NewValue(tftypes.Number, info.Minor),
+		"database":          tftypes.NewValue(tftypes.String, info.Database),
+		"user":              tftypes.NewValue(tftypes.String, info.User),
+		"transactional_ddl": tftypes.NewValue(tftypes.Bool, info.TransactionalDDL),
+		"advisory_locks":    tftypes.NewValue(tftypes.Bool, info.AdvisoryLocks),
+		"if_not_exists":     tftypes.NewValue(tftypes.Bool, info.IfNotExists),
+
+		// just a placeholder, see deprecatedIDAttribute
+		"id": tftypes.NewValue(tftypes.String, ""),
+	}, nil, nil
+}
@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	helper "github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestDataServer(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping long test")
+	}
+
+	products := map[string]string{
+		"postgres":  productPostgres,
+		"cockroach": productCockroachDB,
+		"mysql":     productMySQL,
+		"sqlserver": productSQLServer,
+	}
+
+	for _, server := range testServers {
+		t.Run(server.ServerType, func(t *testing.T) {
+			url, _, err := server.URL()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			helper.UnitTest(t, helper.TestCase{
+				ProtoV6ProviderFactories: protoV6ProviderFactories,
+				Steps: []helper.TestStep{
+					{
+						Config: fmt.Sprintf(`
+provider "sql" {
+	url = %q
+}
+
+data "sql_server" "test" {
+}
+`, url),
+						Check: helper.ComposeTestCheckFunc(
+							helper.TestCheckResourceAttr("data.sql_server.test", "product", products[server.ServerType]),
+							helper.TestCheckResourceAttrSet("data.sql_server.test", "version"),
+							helper.TestCheckResourceAttrSet("data.sql_server.test", "major_version"),
+							helper.TestCheckResourceAttrSet("data.sql_server.test", "user"),
+						),
+					},
+				},
+			})
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/ialexj/terraform-provider-sql/internal/server"
+)
+
+var introspectedTableType = tftypes.Object{
+	AttributeTypes: map[string]tftypes.Type{
+		"schema": tftypes.String,
+		"name":   tftypes.String,
+	},
+}
+
+type dataTables struct {
+	db dbConnector
+}
+
+var _ server.DataSource = (*dataTables)(nil)
+
+func newDataTables(db dbConnector) (*dataTables, error) {
+	return &dataTables{
+		db: db,
+	}, nil
+}
+
+func (d *dataTables) Schema(context.Context) *tfprotov6.Schema {
+	return &tfprotov6.Schema{
+		Block: &tfprotov6.SchemaBlock{
+			Description: "The `sql_tables` datasource lists the tables of a schema, for example to grant privileges " +
+				"on every table.",
+			DescriptionKind: tfprotov6.StringKindMarkdown,
+			Attributes: []*tfprotov6.SchemaAttribute{
+				urlAttribute(),
+				initStatementsAttribute("SQL statements to run when connecting for these tables, after those of the provider."),
+				{
+					Name:            "schema",
+					Optional:        true,
+					Description:     "The schema to list the tables of, defaults to the current schema.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.String,
+				},
+				nameRegexAttribute("tables"),
+				{
+					Name:            "tables",
+					Computed:        true,
+					Description:     "The tables, sorted by name. Views are not included.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.List{ElementType: introspectedTableType},
+				},
+
+				deprecatedIDAttribute(),
+			},
+		},
+	}
+}
+
+func (d *dataTables) Validate(ctx context.Context, config map[string]tftypes.Value) ([]*tfprotov6.Diagnostic, error) {
+	return validateNameRegex(config)
+}
+
+func (d *dataTables) Read(ctx context.Context, config map[string]tftypes.Value) (map[string]tftypes.Value, []*tfprotov6.Diagnostic, error) {
+	dialect, db, schema, err := connectIntrospection(ctx, d.db, config)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	nameRegex, err := nameRegexFromValue(config["name_regex"])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	names, err := dialect.ReadTables(ctx, db, schema)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to read tables: %w", err)
+	}
+
+	tables := []tftypes.Value{}
+	for _, name := range names {
+		if nameRegex != nil && !nameRegex.MatchString(name) {
+			continue
+		}
+		tables = append(tables, tftypes.NewValue(introspectedTableType, map[string]tftypes.Value{
+			"schema": tftypes.NewValue(tftypes.String, schema),
+			"name":   tftypes.NewValue(tftypes.String, name),
+		}))
+	}
+
+	return map[string]tftypes.Value{
+		"url":             config["url"],
+		"init_statements": config["init_statements"],
+		"schema":          config["schema"],
+		"name_regex":      config["name_regex"],
+		"tables":          tftypes.NewValue(tftypes.List{ElementType: introspectedTableType}, tables),
+
+		// just a placeholder, see deprecatedIDAttribute
+		"id": tftypes.NewValue(tftypes.String, ""),
+	}, nil, nil
+}
+
+func nameRegexAttribute(objects string) *tfprotov6.SchemaAttribute {
+	return &tfprotov6.SchemaAttribute{
+		Name:     "name_regex",
+		Optional: true,
+		Description: fmt.Sprintf("A [regular expression](https://github.com/google/re2/wiki/Syntax) the names of the "+
+			"%s must match, ie. `^audit_`. Unanchored, so it matches anywhere in the name.", objects),
+		DescriptionKind: tfprotov6.StringKindMarkdown,
+		Type:            tftypes.String,
+	}
+}
+
+func validateNameRegex(config map[string]tftypes.Value) ([]*tfprotov6.Diagnostic, error) {
+	if !config["name_regex"].IsKnown() {
+		return nil, nil
+	}
+	_, err := nameRegexFromValue(config["name_regex"])
+	if err != nil {
+		return []*tfprotov6.Diagnostic{
+			errorDiag(fmt.Sprintf("Invalid regular expression: %s.", err), tftypes.AttributeName("name_regex")),
+		}, nil
+	}
+	return nil, nil
+}
+
+// nameRegexFromValue compiles the regular expression, it is nil if not set.
+func nameRegexFromValue(v tftypes.Value) (*regexp.Regexp, error) {
+	var s *string
+	err := v.As(&s)
+	if err != nil || s == nil {
+		return nil, err
+	}
+	return regexp.Compile(*s)
+}
+
+// connectIntrospection connects for one of the introspection data sources, and
+// resolves the configured schema, defaulting to the current one.
+func connectIntrospection(ctx context.Context, db dbConnector, config map[string]tftypes.Value) (tableDialect, dbQueryer, string, error) {
+	ds, conn, err := db.GetQueryer(ctx, config)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	d, err := tableDialectFor(ds)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	var schema *string
+	err = config["schema"].As(&schema)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	if schema != nil {
+		return d, conn, *schema, nil
+	}
+
+	current, err := currentSchema(ctx, d, conn)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	return d, conn, current, nil
+}
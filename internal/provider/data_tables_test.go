@@ -0,0 +1,106 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	helper "github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestValidateNameRegex(t *testing.T) {
+	for name, c := range map[string]struct {
+		value tftypes.Value
+		valid bool
+	}{
+		"null":    {tftypes.NewValue(tftypes.String, nil), true},
+		"unknown": {tftypes.NewValue(tftypes.String, tftypes.UnknownValue), true},
+		"valid":   {tftypes.NewValue(tftypes.String, "^audit_"), true},
+		"invalid": {tftypes.NewValue(tftypes.String, "audit_("), false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			diags, err := validateNameRegex(map[string]tftypes.Value{"name_regex": c.value})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if (len(diags) == 0) != c.valid {
+				t.Fatalf("expected valid to be %t, got %v", c.valid, diags)
+			}
+		})
+	}
+}
+
+func TestDataIntrospection(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping long test")
+	}
+
+	for _, server := range testServers {
+		t.Run(server.ServerType, func(t *testing.T) {
+			url, _, err := server.URL()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			helper.UnitTest(t, helper.TestCase{
+				ProtoV6ProviderFactories: protoV6ProviderFactories,
+				Steps: []helper.TestStep{
+					{
+						Config: fmt.Sprintf(`
+provider "sql" {
+	url = %q
+}
+
+resource "sql_table" "test" {
+	name        = "tf_introspection_test"
+	primary_key = ["id"]
+
+	column {
+		name = "id"
+		type = "integer"
+	}
+
+	column {
+		name     = "email"
+		type     = "varchar(100)"
+		nullable = true
+	}
+
+	index {
+		name    = "tf_introspection_test_email"
+		columns = ["email"]
+		unique  = true
+	}
+}
+
+data "sql_tables" "test" {
+	name_regex = "^tf_introspection_"
+	depends_on = [sql_table.test]
+}
+
+data "sql_columns" "test" {
+	table      = sql_table.test.name
+	name_regex = "^email$"
+	depends_on = [sql_table.test]
+}
+
+data "sql_indexes" "test" {
+	table      = sql_table.test.name
+	depends_on = [sql_table.test]
+}
+`, url),
+						Check: helper.ComposeTestCheckFunc(
+							helper.TestCheckResourceAttr("data.sql_tables.test", "tables.#", "1"),
+							helper.TestCheckResourceAttr("data.sql_tables.test", "tables.0.name", "tf_introspection_test"),
+							helper.TestCheckResourceAttr("data.sql_columns.test", "columns.#", "1"),
+							helper.TestCheckResourceAttr("data.sql_columns.test", "columns.0.nullable", "true"),
+							helper.TestCheckResourceAttr("data.sql_indexes.test", "indexes.#", "1"),
+							helper.TestCheckResourceAttr("data.sql_indexes.test", "indexes.0.unique", "true"),
+							helper.TestCheckResourceAttr("data.sql_indexes.test", "indexes.0.columns.0", "email"),
+						),
+					},
+				},
+			})
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"context"
+	"strings"
+)
+
+// database is a database as configured or introspected. Options that are not
+// configured, or not supported by the dialect, are nil.
+type database struct {
+	Name      string
+	Owner     *string
+	Encoding  *string
+	Collation *string
+}
+
+// databaseDialect is implemented by the dialects that support managing
+// databases.
+type databaseDialect interface {
+	Dialect
+
+	// ReadDatabase introspects the database, returning nil if it does not
+	// exist.
+	ReadDatabase(ctx context.Context, db dbQueryer, name string) (*database, error)
+
+	CreateDatabase(d database) ([]string, error)
+	AlterDatabase(prior, planned database) ([]string, error)
+	DropDatabase(name string) string
+
+	// DatabaseRequiresReplace returns the attributes of the database that
+	// can't be changed in place.
+	DatabaseRequiresReplace(prior, planned database) []string
+}
+
+// dbSchema is a schema as configured or introspected, named so as not to be
+// confused with the schema of a table.
+type dbSchema struct {
+	Name  string
+	Owner *string
+}
+
+// schemaDialect is implemented by the dialects that support managing schemas.
+type schemaDialect interface {
+	Dialect
+
+	// ReadSchema introspects the schema, returning nil if it does not exist.
+	ReadSchema(ctx context.Context, db dbQueryer, name string) (*dbSchema, error)
+
+	CreateSchema(s dbSchema) ([]string, error)
+	AlterSchema(prior, planned dbSchema) ([]string, error)
+	DropSchema(name string) string
+}
+
+// equalFoldStringPtr compares the strings case insensitively, as encodings
+// and collations are.
+func equalFoldStringPtr(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return strings.EqualFold(*a, *b)
+}
@@ -0,0 +1,133 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCreateDatabase(t *testing.T) {
+	owner, encoding, collation := "app", "UTF8", "C"
+
+	for name, c := range map[string]struct {
+		dialect  databaseDialect
+		database database
+		expected []string
+	}{
+		"pgx": {
+			postgresDialect{},
+			database{Name: "app", Owner: &owner, Encoding: &encoding, Collation: &collation},
+			[]string{`CREATE DATABASE "app" WITH OWNER "app" TEMPLATE template0 ENCODING 'UTF8' LC_COLLATE 'C'`},
+		},
+		"pgx defaults": {
+			postgresDialect{},
+			database{Name: "app"},
+			[]string{`CREATE DATABASE "app"`},
+		},
+		"mysql": {
+			mysqlDialect{},
+			database{Name: "app", Encoding: &encoding, Collation: &collation},
+			[]string{"CREATE DATABASE `app` CHARACTER SET UTF8 COLLATE C"},
+		},
+		"sqlserver": {
+			sqlServerDialect{},
+			database{Name: "app", Owner: &owner, Collation: &collation},
+			[]string{
+				"CREATE DATABASE [app] COLLATE C",
+				"ALTER AUTHORIZATION ON DATABASE::[app] TO [app]",
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			actual, err := c.dialect.CreateDatabase(c.database)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(c.expected, actual); diff != "" {
+				t.Fatalf("unexpected statements (-expected +actual):\n%s", diff)
+			}
+		})
+	}
+
+	if _, err := (mysqlDialect{}).CreateDatabase(database{Name: "app", Owner: &owner}); err == nil {
+		t.Error("expected an error for a MySQL owner")
+	}
+	if _, err := (sqlServerDialect{}).CreateDatabase(database{Name: "app", Encoding: &encoding}); err == nil {
+		t.Error("expected an error for a SQL Server encoding")
+	}
+}
+
+func TestAlterDatabase(t *testing.T) {
+	oldOwner, newOwner := "old", "new"
+	oldCollation, newCollation := "utf8mb4_general_ci", "utf8mb4_bin"
+	prior := database{Name: "app", Owner: &oldOwner, Collation: &oldCollation}
+	planned := database{Name: "app", Owner: &newOwner, Collation: &newCollation}
+
+	for name, c := range map[string]struct {
+		dialect  databaseDialect
+		prior    database
+		planned  database
+		expected []string
+		replace  []string
+	}{
+		"pgx": {
+			postgresDialect{},
+			prior,
+			planned,
+			[]string{`ALTER DATABASE "app" OWNER TO "new"`},
+			[]string{"collation"},
+		},
+		"mysql": {
+			mysqlDialect{},
+			database{Name: "app", Collation: &oldCollation},
+			database{Name: "app", Collation: &newCollation},
+			[]string{"ALTER DATABASE `app` COLLATE utf8mb4_bin"},
+			nil,
+		},
+		"sqlserver": {
+			sqlServerDialect{},
+			prior,
+			planned,
+			[]string{
+				"ALTER DATABASE [app] COLLATE utf8mb4_bin",
+				"ALTER AUTHORIZATION ON DATABASE::[app] TO [new]",
+			},
+			nil,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			actual, err := c.dialect.AlterDatabase(c.prior, c.planned)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(c.expected, actual); diff != "" {
+				t.Fatalf("unexpected statements (-expected +actual):\n%s", diff)
+			}
+			if diff := cmp.Diff(c.replace, c.dialect.DatabaseRequiresReplace(c.prior, c.planned)); diff != "" {
+				t.Fatalf("unexpected replacement (-expected +actual):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCreateSchema(t *testing.T) {
+	owner := "app"
+
+	for name, c := range map[string]struct {
+		dialect  schemaDialect
+		expected []string
+	}{
+		"pgx":       {postgresDialect{}, []string{`CREATE SCHEMA "reporting" AUTHORIZATION "app"`}},
+		"sqlserver": {sqlServerDialect{}, []string{"CREATE SCHEMA [reporting] AUTHORIZATION [app]"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			actual, err := c.dialect.CreateSchema(dbSchema{Name: "reporting", Owner: &owner})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(c.expected, actual); diff != "" {
+				t.Fatalf("unexpected statements (-expected +actual):\n%s", diff)
+			}
+		})
+	}
+}
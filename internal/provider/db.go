@@ -25,15 +25,31 @@ type dbQueryer interface {
 	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
 }
 
+// dbTxQueryer can run queries directly or within a transaction.
+type dbTxQueryer interface {
+	dbQueryer
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+}
+
 type dbExecer interface {
 	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
 }
 
+// dbQueryExecer can both introspect and change the database, for resources
+// that only run the statements needed to reach the planned state.
+type dbQueryExecer interface {
+	dbQueryer
+	dbExecer
+}
+
 type dbConnector interface {
 	HasUrl() bool
 	GetDataSource(url tftypes.Value) (dataSource, error)
-	GetQueryer(ctx context.Context, url tftypes.Value) (dataSource, dbQueryer, error)
-	GetExecer(ctx context.Context, url tftypes.Value) (dataSource, dbExecer, error)
+	// GetQueryer and GetExecer connect using the url and init_statements of the
+	// resource or data source config, if set, in addition to the provider's.
+	GetQueryer(ctx context.Context, config map[string]tftypes.Value) (dataSource, dbTxQueryer, error)
+	GetExecer(ctx context.Context, config map[string]tftypes.Value) (dataSource, dbExecer, error)
+	GetQueryExecer(ctx context.Context, config map[string]tftypes.Value) (dataSource, dbQueryExecer, error)
 }
 
 type dataSource struct {
@@ -53,43 +69,100 @@ func (p *provider) GetDataSource(url tftypes.Value) (dataSource, error) {
 	return parseUrlValue(url)
 }
 
-func (p *provider) GetQueryer(ctx context.Context, url tftypes.Value) (dataSource, dbQueryer, error) {
-	return p.connect(ctx, url)
+func (p *provider) GetQueryer(ctx context.Context, config map[string]tftypes.Value) (dataSource, dbTxQueryer, error) {
+	return p.connect(ctx, config)
+}
+
+func (p *provider) GetExecer(ctx context.Context, config map[string]tftypes.Value) (dataSource, dbExecer, error) {
+	return p.connect(ctx, config)
 }
 
-func (p *provider) GetExecer(ctx context.Context, url tftypes.Value) (dataSource, dbExecer, error) {
-	return p.connect(ctx, url)
+func (p *provider) GetQueryExecer(ctx context.Context, config map[string]tftypes.Value) (dataSource, dbQueryExecer, error) {
+	return p.connect(ctx, config)
 }
 
-func (p *provider) connect(ctx context.Context, url tftypes.Value) (dataSource, *sql.DB, error) {
+func (p *provider) connect(ctx context.Context, config map[string]tftypes.Value) (dataSource, *sql.DB, error) {
 	var err error
 	var ds dataSource
 	var db *sql.DB
 
-	ds, err = p.GetDataSource(url)
+	ds, err = p.GetDataSource(config["url"])
 	if err != nil {
 		return ds, nil, err
 	}
 
-	db, err = sql.Open(string(ds.driver), ds.url)
+	initStatements, err := initStatementsFromValue(config["init_statements"])
 	if err != nil {
-		return ds, nil, fmt.Errorf("unable to open database: %w", err)
+		return ds, nil, err
 	}
 
-	db.SetMaxOpenConns(int(p.MaxOpenConns))
-	db.SetMaxIdleConns(int(p.MaxIdleConns))
+	opts := connectorOptions{
+		TLS:            p.tlsConfig,
+		InitStatements: append(append([]string(nil), p.initStatements...), initStatements...),
+		Password:       p.password,
+	}
+	if p.awsIAMAuth {
+		opts.Password, err = rdsIAMPassword(ds)
+		if err != nil {
+			return ds, nil, err
+		}
+		opts.CleartextPassword = true
+	}
+	if p.sshTunnel != nil {
+		opts.Dialer = p.sshTunnel
+	}
+
+	err = p.connectRetry.do(ctx, func() error {
+		db, err = openDB(ds, opts)
+		if err != nil {
+			return fmt.Errorf("unable to open database: %w", ds.scrubError(err))
+		}
+
+		db.SetMaxOpenConns(int(p.MaxOpenConns))
+		db.SetMaxIdleConns(int(p.MaxIdleConns))
+		db.SetConnMaxLifetime(p.ConnMaxLifetime)
+		db.SetConnMaxIdleTime(p.ConnMaxIdleTime)
+
+		err = db.PingContext(ctx)
+		if err != nil {
+			db.Close()
+			return fmt.Errorf("connectContext - unable to ping database: %w", ds.scrubError(err))
+		}
 
-	err = db.PingContext(ctx)
+		return nil
+	})
 	if err != nil {
-		return ds, nil, fmt.Errorf("connectContext - unable to ping database: %w", err)
+		return ds, nil, err
 	}
 
-	tflog.SetField(ctx, "db_driver", ds)
+	ctx = tflog.SetField(ctx, "db_driver", string(ds.driver))
+	ctx = tflog.SetField(ctx, "db_url", ds.redactedURL())
 	tflog.Info(ctx, "Database connection established.")
 
 	return ds, db, nil
 }
 
+// queryStrings runs a query returning a single column of strings.
+func queryStrings(ctx context.Context, db dbQueryer, query string, args ...interface{}) ([]string, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var strs []string
+	for rows.Next() {
+		var s string
+		err = rows.Scan(&s)
+		if err != nil {
+			return nil, err
+		}
+		strs = append(strs, s)
+	}
+
+	return strs, rows.Err()
+}
+
 func parseUrlValue(value tftypes.Value) (dataSource, error) {
 	if !value.IsKnown() {
 		return dataSource{}, fmt.Errorf("url is not yet known")
@@ -115,27 +188,12 @@ func parseUrl(url string) (dataSource, error) {
 		return dataSource{}, err
 	}
 
-	switch scheme {
-	case "postgres", "postgresql":
-		// TODO: use consts for these driver names?
-		return dataSource{driver: "pgx", url: url}, nil
-
-	case "mysql":
-		return dataSource{driver: "mysql", url: strings.TrimPrefix(url, "mysql://")}, nil
-
-		// TODO: multistatements? see go-migrate's implementation
-		// https://github.com/golang-migrate/migrate/blob/master/database/mysql/mysql.go
-		// TODO: also set parseTime=true https://github.com/go-sql-driver/mysql#parsetime
-
-	case "azuresql":
-		return dataSource{driver: "azuresql", url: strings.Replace(url, "azuresql://", "sqlserver://", 1)}, nil
-
-	case "sqlserver":
-		return dataSource{driver: "sqlserver", url: url}, nil
-
-	default:
+	d := dialectForScheme(scheme)
+	if d == nil {
 		return dataSource{}, fmt.Errorf("unsupported driver: %q", scheme)
 	}
+
+	return dataSource{driver: d.Driver(), url: d.DataSourceName(url)}, nil
 }
 
 func schemeFromURL(url string) (string, error) {
@@ -252,32 +310,9 @@ func typeAndValueForColType(driver driverName, colType *sql.ColumnType) (tftypes
 	scanType := colType.ScanType()
 	kind := scanType.Kind()
 
-	switch driver {
-	case "sqlserver":
-		switch dbName := colType.DatabaseTypeName(); dbName {
-		case "UNIQUEIDENTIFIER":
-			return tftypes.String, reflect.TypeOf((*sqlServerUniqueIdentifier)(nil)).Elem(), nil
-		case "DECIMAL", "MONEY", "SMALLMONEY":
-			// TODO: add diags about converting to numeric?
-			return tftypes.String, reflect.TypeOf((*sql.NullString)(nil)).Elem(), nil
-		}
-	case "mysql":
-		switch dbName := colType.DatabaseTypeName(); dbName {
-		case "YEAR":
-			return tftypes.Number, reflect.TypeOf((*sql.NullInt32)(nil)).Elem(), nil
-		case "VARCHAR", "DECIMAL", "TIME", "JSON":
-			return tftypes.String, reflect.TypeOf((*sql.NullString)(nil)).Elem(), nil
-		case "DATE", "DATETIME":
-			return tftypes.String, reflect.TypeOf((*sql.NullTime)(nil)).Elem(), nil
-		}
-	case "pgx":
-		switch dbName := colType.DatabaseTypeName(); dbName {
-		// 790 is the oid of money
-		case "MONEY", "790":
-			// TODO: add diags about converting to numeric?
-			return tftypes.String, reflect.TypeOf((*sql.NullString)(nil)).Elem(), nil
-		case "TIMESTAMPTZ", "TIMESTAMP", "DATE":
-			return tftypes.String, reflect.TypeOf((*sql.NullTime)(nil)).Elem(), nil
+	if d := dialectFor(driver); d != nil {
+		if ty, rty, ok := d.ColumnType(colType); ok {
+			return ty, rty, nil
 		}
 	}
 
@@ -0,0 +1,114 @@
+package provider
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// driverName is the name of a database/sql driver.
+type driverName string
+
+const (
+	driverPgx       driverName = "pgx"
+	driverMySQL     driverName = "mysql"
+	driverSQLServer driverName = "sqlserver"
+	driverAzureSQL  driverName = "azuresql"
+)
+
+// Dialect is the driver specific behaviour of a database. Each driver has a
+// single implementation, registered in dialects.
+type Dialect interface {
+	// Driver returns the database/sql driver name.
+	Driver() driverName
+
+	// Schemes returns the URL schemes handled by the dialect, the first is
+	// used when building URLs.
+	Schemes() []string
+
+	// DataSourceName converts a URL with one of the schemes to the DSN
+	// expected by the driver.
+	DataSourceName(url string) string
+
+	// ConnectionURL assembles the settings of a connection block in to a URL.
+	ConnectionURL(c connectionConfig) (string, error)
+
+	// Connector builds a connector for the DSN, with the options that can't be
+	// expressed in the DSN itself.
+	Connector(dsn string, opts connectorOptions) (driver.Connector, error)
+
+	// QuoteIdentifier quotes a single identifier, ie. a table name.
+	QuoteIdentifier(name string) string
+
+	// QuoteString quotes a string literal, for statements such as DDL that
+	// can't take parameters.
+	QuoteString(s string) string
+
+	// Placeholder returns the n-th parameter placeholder, n is 1-based.
+	Placeholder(n int) string
+
+	// ColumnType maps driver specific column types to their Terraform type and
+	// the type to scan them in to, ok is false to use the default mapping of
+	// the scan type.
+	ColumnType(colType *sql.ColumnType) (ty tftypes.Type, scanType reflect.Type, ok bool)
+
+	// BeginReadOnly starts a transaction that is not allowed to write, it
+	// should always be rolled back.
+	BeginReadOnly(ctx context.Context, db dbTxQueryer) (*sql.Tx, error)
+
+	// AcquireLock takes an exclusive session lock with the name on the
+	// connection, waiting until it is available. The returned func releases
+	// it.
+	AcquireLock(ctx context.Context, conn *sql.Conn, name string) (release func(context.Context) error, err error)
+
+	// VersionQuery returns a query for the version string of the server.
+	VersionQuery() string
+
+	// SplitStatements splits a script in to the batches that are executed
+	// separately.
+	SplitStatements(script string) []string
+}
+
+// dialects are all of the supported dialects.
+var dialects = []Dialect{
+	postgresDialect{},
+	mysqlDialect{},
+	sqlServerDialect{},
+	azureSQLDialect{},
+}
+
+// dialectFor returns the dialect of the driver, or nil if it isn't supported.
+func dialectFor(driver driverName) Dialect {
+	for _, d := range dialects {
+		if d.Driver() == driver {
+			return d
+		}
+	}
+	return nil
+}
+
+// dialectForScheme returns the dialect of the URL scheme, or nil if it isn't
+// supported.
+func dialectForScheme(scheme string) Dialect {
+	for _, d := range dialects {
+		for _, s := range d.Schemes() {
+			if s == scheme {
+				return d
+			}
+		}
+	}
+	return nil
+}
+
+// quoteString quotes s as a standard SQL string literal, doubling any quotes.
+func quoteString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+func (ds dataSource) dialect() Dialect {
+	return dialectFor(ds.driver)
+}
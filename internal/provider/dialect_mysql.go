@@ -0,0 +1,737 @@
+package provider
+
+import (
+	"context"
+	"crypto/tls"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"net"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// mysqlDialect is used for MySQL and compatible servers, such as MariaDB.
+type mysqlDialect struct{}
+
+var _ Dialect = mysqlDialect{}
+
+func (mysqlDialect) Driver() driverName {
+	return driverMySQL
+}
+
+func (mysqlDialect) Schemes() []string {
+	return []string{"mysql"}
+}
+
+func (mysqlDialect) DataSourceName(url string) string {
+	// TODO: multistatements? see go-migrate's implementation
+	// https://github.com/golang-migrate/migrate/blob/master/database/mysql/mysql.go
+	// TODO: also set parseTime=true https://github.com/go-sql-driver/mysql#parsetime
+	return strings.TrimPrefix(url, "mysql://")
+}
+
+func (mysqlDialect) ConnectionURL(c connectionConfig) (string, error) {
+	// the mysql driver does not use a URL, but its own DSN format with the
+	// address wrapped in the protocol, ie. tcp(host:port)
+	cfg := mysql.NewConfig()
+	cfg.User = c.Username
+	cfg.Passwd = c.Password
+	cfg.Net = "tcp"
+	cfg.Addr = c.hostPort()
+	cfg.DBName = c.Database
+
+	dsn := cfg.FormatDSN()
+	if query := c.query(); len(query) > 0 {
+		sep := "?"
+		if strings.Contains(dsn, "?") {
+			sep = "&"
+		}
+		dsn += sep + query.Encode()
+	}
+	return "mysql://" + dsn, nil
+}
+
+func (mysqlDialect) Connector(dsn string, opts connectorOptions) (driver.Connector, error) {
+	cfg, err := mysql.ParseDSN(dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.CleartextPassword {
+		cfg.AllowCleartextPasswords = true
+	}
+
+	if opts.Dialer != nil {
+		// dialers can only be referenced by name, as a network
+		cfg.Net = registerMySQLDialer(opts.Dialer)
+	}
+
+	var c driver.Connector
+	if opts.Password != nil {
+		c = &passwordConnector{
+			driver:   mysql.MySQLDriver{},
+			password: opts.Password,
+			connector: func(password string) (driver.Connector, error) {
+				cfg := cfg.Clone()
+				cfg.Passwd = password
+				return newMySQLConnector(cfg, opts.TLS)
+			},
+		}
+	} else {
+		c, err = newMySQLConnector(cfg, opts.TLS)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(opts.InitStatements) > 0 {
+		c = &initConnector{Connector: c, statements: opts.InitStatements}
+	}
+
+	return c, nil
+}
+
+var (
+	mysqlRegistryMu sync.Mutex
+	// mysqlRegistrations numbers the names registered with the mysql driver,
+	// so they are unique within the process.
+	mysqlRegistrations int
+	mysqlDialers       = map[contextDialer]string{}
+)
+
+// mysqlRegistryName returns a new unique name for the mysql driver's global
+// registries, the lock must be held.
+func mysqlRegistryName() string {
+	mysqlRegistrations++
+	return fmt.Sprintf("terraform-provider-sql-%d", mysqlRegistrations)
+}
+
+// newMySQLConnector creates a connector for the config using the TLS config if
+// set. The mysql driver can only reference TLS configs by name, so it is
+// registered just until the connector has copied it.
+func newMySQLConnector(cfg *mysql.Config, tlsConfig *tls.Config) (driver.Connector, error) {
+	if tlsConfig == nil {
+		return mysql.NewConnector(cfg)
+	}
+
+	mysqlRegistryMu.Lock()
+	key := mysqlRegistryName()
+	mysqlRegistryMu.Unlock()
+
+	err := mysql.RegisterTLSConfig(key, tlsConfig.Clone())
+	if err != nil {
+		return nil, err
+	}
+	defer mysql.DeregisterTLSConfig(key)
+
+	cfg = cfg.Clone()
+	cfg.TLSConfig = key
+	return mysql.NewConnector(cfg)
+}
+
+// registerMySQLDialer registers the dialer with the mysql driver as a network,
+// returning the name of the network. The driver can't deregister networks, so
+// each dialer is registered once and kept for the life of the process, there
+// is one per provider configuration with an SSH tunnel.
+func registerMySQLDialer(d contextDialer) string {
+	mysqlRegistryMu.Lock()
+	defer mysqlRegistryMu.Unlock()
+
+	if network, ok := mysqlDialers[d]; ok {
+		return network
+	}
+
+	network := mysqlRegistryName()
+	mysql.RegisterDialContext(network, func(ctx context.Context, addr string) (net.Conn, error) {
+		return d.DialContext(ctx, "tcp", addr)
+	})
+	mysqlDialers[d] = network
+
+	return network
+}
+
+func (mysqlDialect) QuoteIdentifier(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
+
+func (mysqlDialect) QuoteString(s string) string {
+	// backslashes are escape characters unless NO_BACKSLASH_ESCAPES is set
+	return quoteString(strings.ReplaceAll(s, `\`, `\\`))
+}
+
+func (mysqlDialect) Placeholder(n int) string {
+	return "?"
+}
+
+func (mysqlDialect) ColumnType(colType *sql.ColumnType) (tftypes.Type, reflect.Type, bool) {
+	switch colType.DatabaseTypeName() {
+	case "YEAR":
+		return tftypes.Number, reflect.TypeOf((*sql.NullInt32)(nil)).Elem(), true
+	case "VARCHAR", "DECIMAL", "TIME", "JSON":
+		return tftypes.String, reflect.TypeOf((*sql.NullString)(nil)).Elem(), true
+	case "DATE", "DATETIME":
+		return tftypes.String, reflect.TypeOf((*sql.NullTime)(nil)).Elem(), true
+	}
+
+	return nil, nil, false
+}
+
+func (mysqlDialect) BeginReadOnly(ctx context.Context, db dbTxQueryer) (*sql.Tx, error) {
+	return db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+}
+
+func (mysqlDialect) AcquireLock(ctx context.Context, conn *sql.Conn, name string) (func(context.Context) error, error) {
+	// a negative timeout waits indefinitely, or until the context is done
+	var acquired sql.NullInt64
+	err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, -1)", name).Scan(&acquired)
+	if err != nil {
+		return nil, fmt.Errorf("unable to acquire lock %q: %w", name, err)
+	}
+	if acquired.Int64 != 1 {
+		return nil, fmt.Errorf("unable to acquire lock %q", name)
+	}
+
+	return func(ctx context.Context) error {
+		_, err := conn.ExecContext(ctx, "SELECT RELEASE_LOCK(?)", name)
+		return err
+	}, nil
+}
+
+func (mysqlDialect) VersionQuery() string {
+	return "SELECT VERSION()"
+}
+
+func (mysqlDialect) SplitStatements(script string) []string {
+	// splitting on semicolons would break routine bodies, multiple statements
+	// can be enabled with the multiStatements parameter instead
+	return []string{script}
+}
+
+var _ tableDialect = mysqlDialect{}
+
+func (mysqlDialect) CurrentSchema() string {
+	return "DATABASE()"
+}
+
+var mysqlTypeAliases = map[string]string{
+	"integer":           "int",
+	"bool":              "tinyint",
+	"boolean":           "tinyint",
+	"dec":               "decimal",
+	"numeric":           "decimal",
+	"fixed":             "decimal",
+	"double precision":  "double",
+	"real":              "double",
+	"character":         "char",
+	"character varying": "varchar",
+}
+
+// mysqlIntegerTypes have a display width, which is deprecated and no longer
+// reported by MySQL 8.
+var mysqlIntegerTypes = map[string]bool{
+	"tinyint":   true,
+	"smallint":  true,
+	"mediumint": true,
+	"int":       true,
+	"bigint":    true,
+}
+
+func (mysqlDialect) NormalizeColumnType(colType string) string {
+	base, params, rest := splitColumnType(colType)
+
+	// modifiers without parameters are part of the base, ie. int unsigned
+	if base != "double precision" && base != "character varying" {
+		if name, modifiers, ok := strings.Cut(base, " "); ok {
+			base = name
+			rest = strings.TrimSpace(modifiers + " " + rest)
+		}
+	}
+
+	if alias, ok := mysqlTypeAliases[base]; ok {
+		if base == "bool" || base == "boolean" {
+			params = "1"
+		}
+		base = alias
+	}
+
+	switch {
+	case mysqlIntegerTypes[base] && !(base == "tinyint" && params == "1"):
+		params = ""
+	case base == "decimal" && params == "":
+		params = "10,0"
+	case base == "decimal" && !strings.Contains(params, ","):
+		params += ",0"
+	case base == "char" && params == "":
+		params = "1"
+	}
+
+	return joinColumnType(base, params, rest)
+}
+
+// mysqlDefaultKeyword matches the keywords and functions without arguments
+// that MySQL allows as literal defaults, ie. CURRENT_TIMESTAMP(3).
+var mysqlDefaultKeyword = regexp.MustCompile(`^[A-Za-z_]+(\(\d*\))?$`)
+
+func (mysqlDialect) NormalizeDefault(dflt string) string {
+	// literals are reported without their quotes, and expressions without
+	// their parentheses
+	dflt = trimParens(strings.TrimSpace(dflt))
+	if value, ok := unquoteDefault(dflt); ok {
+		dflt = value
+	}
+	if mysqlDefaultKeyword.MatchString(dflt) {
+		dflt = strings.ToLower(dflt)
+	}
+	return dflt
+}
+
+func (mysqlDialect) ReadTables(ctx context.Context, db dbQueryer, schema string) ([]string, error) {
+	return queryStrings(ctx, db, `SELECT TABLE_NAME
+FROM information_schema.TABLES
+WHERE TABLE_SCHEMA = ? AND TABLE_TYPE = 'BASE TABLE'
+ORDER BY TABLE_NAME`, schema)
+}
+
+func (mysqlDialect) ReadColumns(ctx context.Context, db dbQueryer, schema, name string) ([]tableColumn, error) {
+	rows, err := db.QueryContext(ctx, `SELECT COLUMN_NAME, COLUMN_TYPE, IS_NULLABLE, COLUMN_DEFAULT, EXTRA
+FROM information_schema.COLUMNS
+WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ?
+ORDER BY ORDINAL_POSITION`, schema, name)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var columns []tableColumn
+	for rows.Next() {
+		var (
+			c               tableColumn
+			nullable, extra string
+			dflt            sql.NullString
+		)
+		err = rows.Scan(&c.Name, &c.Type, &nullable, &dflt, &extra)
+		if err != nil {
+			return nil, err
+		}
+
+		// auto increment is configured as part of the type
+		if strings.Contains(strings.ToLower(extra), "auto_increment") {
+			c.Type += " auto_increment"
+		}
+
+		c.Nullable = nullable == "YES"
+		if dflt.Valid {
+			c.Default = &dflt.String
+		}
+
+		columns = append(columns, c)
+	}
+
+	return columns, rows.Err()
+}
+
+func (mysqlDialect) ReadIndexes(ctx context.Context, db dbQueryer, schema, name string) ([]tableIndex, error) {
+	rows, err := db.QueryContext(ctx, `SELECT INDEX_NAME, NON_UNIQUE = 0, COLUMN_NAME
+FROM information_schema.STATISTICS
+WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ? AND INDEX_NAME <> 'PRIMARY'
+ORDER BY INDEX_NAME, SEQ_IN_INDEX`, schema, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanIndexes(rows)
+}
+
+func (d mysqlDialect) AddColumn(schema, name string, c tableColumn) []string {
+	return []string{
+		fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s", qualifiedName(d, schema, name), columnDefinition(d, c)),
+	}
+}
+
+func (d mysqlDialect) AlterColumn(schema, name string, prior, planned tableColumn) []string {
+	// the whole definition is replaced, so any unchanged parts are restated
+	return []string{
+		fmt.Sprintf("ALTER TABLE %s MODIFY COLUMN %s", qualifiedName(d, schema, name), columnDefinition(d, planned)),
+	}
+}
+
+func (d mysqlDialect) DropColumn(schema, name string, c tableColumn) []string {
+	return []string{
+		fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s", qualifiedName(d, schema, name), d.QuoteIdentifier(c.Name)),
+	}
+}
+
+func (d mysqlDialect) DropIndex(schema, name string, index tableIndex) string {
+	return fmt.Sprintf("DROP INDEX %s ON %s", d.QuoteIdentifier(index.Name), qualifiedName(d, schema, name))
+}
+
+var _ roleDialect = mysqlDialect{}
+
+// account quotes the name as an account for any host, roles and users are
+// both accounts in MySQL.
+func (d mysqlDialect) account(name string) string {
+	return d.QuoteString(name) + "@'%'"
+}
+
+func (mysqlDialect) ReadRole(ctx context.Context, db dbQueryer, name string) (*role, error) {
+	rows, err := db.QueryContext(ctx, "SELECT account_locked = 'N', max_user_connections FROM mysql.user WHERE User = ? AND Host = '%'", name)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return nil, rows.Err()
+	}
+
+	r := &role{Name: name}
+	err = rows.Scan(&r.Login, &r.ConnectionLimit)
+	if err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	// zero is unlimited
+	if r.ConnectionLimit == 0 {
+		r.ConnectionLimit = -1
+	}
+
+	r.Roles, err = queryStrings(ctx, db, "SELECT FROM_USER FROM mysql.role_edges WHERE TO_USER = ? AND TO_HOST = '%' ORDER BY FROM_USER", name)
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (d mysqlDialect) roleOptions(r role) string {
+	limit := r.ConnectionLimit
+	if limit < 0 {
+		limit = 0
+	}
+
+	lock := "ACCOUNT LOCK"
+	if r.Login {
+		lock = "ACCOUNT UNLOCK"
+	}
+	return fmt.Sprintf("WITH MAX_USER_CONNECTIONS %d %s", limit, lock)
+}
+
+// defaultRoles activates the granted roles on login, as they are inherited in
+// the other databases.
+func (d mysqlDialect) defaultRoles(r role) string {
+	return "SET DEFAULT ROLE ALL TO " + d.account(r.Name)
+}
+
+func (d mysqlDialect) CreateRole(r role) ([]string, error) {
+	create := "CREATE USER " + d.account(r.Name)
+	if r.Password != nil {
+		create += " IDENTIFIED BY " + d.QuoteString(*r.Password)
+	}
+
+	stmts := []string{create + " " + d.roleOptions(r)}
+	for _, member := range r.Roles {
+		stmts = append(stmts, fmt.Sprintf("GRANT %s TO %s", d.account(member), d.account(r.Name)))
+	}
+	if len(r.Roles) > 0 {
+		stmts = append(stmts, d.defaultRoles(r))
+	}
+	return stmts, nil
+}
+
+func (d mysqlDialect) AlterRole(prior, planned role) ([]string, error) {
+	account := d.account(planned.Name)
+
+	var stmts []string
+	if prior.Login != planned.Login || prior.ConnectionLimit != planned.ConnectionLimit {
+		stmts = append(stmts, fmt.Sprintf("ALTER USER %s %s", account, d.roleOptions(planned)))
+	}
+	if !equalStringPtr(prior.Password, planned.Password) {
+		password := ""
+		if planned.Password != nil {
+			password = *planned.Password
+		}
+		stmts = append(stmts, fmt.Sprintf("ALTER USER %s IDENTIFIED BY %s", account, d.QuoteString(password)))
+	}
+
+	added, removed := diffStrings(prior.Roles, planned.Roles)
+	for _, member := range removed {
+		stmts = append(stmts, fmt.Sprintf("REVOKE %s FROM %s", d.account(member), account))
+	}
+	for _, member := range added {
+		stmts = append(stmts, fmt.Sprintf("GRANT %s TO %s", d.account(member), account))
+	}
+	if len(added) > 0 {
+		stmts = append(stmts, d.defaultRoles(planned))
+	}
+	return stmts, nil
+}
+
+func (d mysqlDialect) DropRole(r role) []string {
+	return []string{"DROP USER " + d.account(r.Name)}
+}
+
+func (mysqlDialect) RoleRequiresReplace(prior, planned role) []string {
+	return nil
+}
+
+var _ grantDialect = mysqlDialect{}
+
+func (d mysqlDialect) ReadGrants(ctx context.Context, db dbQueryer, role, objectType, schema string) (map[string]objectPrivileges, error) {
+	var rows *sql.Rows
+	var err error
+	switch objectType {
+	case grantObjectTable:
+		rows, err = db.QueryContext(ctx, `SELECT TABLE_NAME, PRIVILEGE_TYPE, IS_GRANTABLE = 'YES'
+FROM information_schema.TABLE_PRIVILEGES
+WHERE GRANTEE = ? AND TABLE_SCHEMA = COALESCE(NULLIF(?, ''), DATABASE())`, d.account(role), schema)
+	case grantObjectSchema:
+		rows, err = db.QueryContext(ctx, `SELECT TABLE_SCHEMA, PRIVILEGE_TYPE, IS_GRANTABLE = 'YES'
+FROM information_schema.SCHEMA_PRIVILEGES
+WHERE GRANTEE = ?`, d.account(role))
+	default:
+		return nil, fmt.Errorf("unsupported object type %q", objectType)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return scanGrants(rows)
+}
+
+// GrantTarget returns all tables in the database for a schema, as databases
+// and schemas are the same thing in MySQL.
+func (d mysqlDialect) GrantTarget(objectType, schema, object string) string {
+	if objectType == grantObjectSchema {
+		return d.QuoteIdentifier(object) + ".*"
+	}
+	return qualifiedName(d, schema, object)
+}
+
+func (d mysqlDialect) Grantee(role string) string {
+	return d.account(role)
+}
+
+func (mysqlDialect) RevokeStatement(privileges []string, target, grantee string, grantable bool) string {
+	return fmt.Sprintf("REVOKE %s ON %s FROM %s", strings.Join(privileges, ", "), target, grantee)
+}
+
+// RevokeGrantOptionStatement revokes the grant option of all privileges on
+// the target, as it is not granted per privilege in MySQL.
+func (mysqlDialect) RevokeGrantOptionStatement(privileges []string, target, grantee string) string {
+	return fmt.Sprintf("REVOKE GRANT OPTION ON %s FROM %s", target, grantee)
+}
+
+// AllPrivileges excludes GRANT OPTION, which ALL does not grant.
+func (mysqlDialect) AllPrivileges(objectType string) []string {
+	switch objectType {
+	case grantObjectTable:
+		return []string{
+			"ALTER", "CREATE", "CREATE VIEW", "DELETE", "DROP", "INDEX", "INSERT", "REFERENCES", "SELECT",
+			"SHOW VIEW", "TRIGGER", "UPDATE",
+		}
+	case grantObjectSchema:
+		return []string{
+			"ALTER", "ALTER ROUTINE", "CREATE", "CREATE ROUTINE", "CREATE TEMPORARY TABLES", "CREATE VIEW", "DELETE",
+			"DROP", "EVENT", "EXECUTE", "INDEX", "INSERT", "LOCK TABLES", "REFERENCES", "SELECT", "SHOW VIEW",
+			"TRIGGER", "UPDATE",
+		}
+	}
+	return nil
+}
+
+var _ databaseDialect = mysqlDialect{}
+
+func (mysqlDialect) ReadDatabase(ctx context.Context, db dbQueryer, name string) (*database, error) {
+	rows, err := db.QueryContext(ctx, "SELECT DEFAULT_CHARACTER_SET_NAME, DEFAULT_COLLATION_NAME FROM information_schema.SCHEMATA WHERE SCHEMA_NAME = ?", name)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return nil, rows.Err()
+	}
+
+	d := &database{Name: name}
+	err = rows.Scan(&d.Encoding, &d.Collation)
+	if err != nil {
+		return nil, err
+	}
+	return d, rows.Err()
+}
+
+// databaseOptions returns the character set and collation clauses of the
+// options that changed from prior.
+func (d mysqlDialect) databaseOptions(prior, planned database) []string {
+	var opts []string
+	if planned.Encoding != nil && !equalFoldStringPtr(prior.Encoding, planned.Encoding) {
+		opts = append(opts, "CHARACTER SET "+*planned.Encoding)
+	}
+	if planned.Collation != nil && !equalFoldStringPtr(prior.Collation, planned.Collation) {
+		opts = append(opts, "COLLATE "+*planned.Collation)
+	}
+	return opts
+}
+
+func (d mysqlDialect) CreateDatabase(db database) ([]string, error) {
+	if db.Owner != nil {
+		return nil, fmt.Errorf("database owners are not supported by MySQL")
+	}
+
+	create := "CREATE DATABASE " + d.QuoteIdentifier(db.Name)
+	if opts := d.databaseOptions(database{}, db); len(opts) > 0 {
+		create += " " + strings.Join(opts, " ")
+	}
+	return []string{create}, nil
+}
+
+func (d mysqlDialect) AlterDatabase(prior, planned database) ([]string, error) {
+	if planned.Owner != nil {
+		return nil, fmt.Errorf("database owners are not supported by MySQL")
+	}
+
+	opts := d.databaseOptions(prior, planned)
+	if len(opts) == 0 {
+		return nil, nil
+	}
+	return []string{"ALTER DATABASE " + d.QuoteIdentifier(planned.Name) + " " + strings.Join(opts, " ")}, nil
+}
+
+func (d mysqlDialect) DropDatabase(name string) string {
+	return "DROP DATABASE " + d.QuoteIdentifier(name)
+}
+
+func (mysqlDialect) DatabaseRequiresReplace(prior, planned database) []string {
+	return nil
+}
+
+// schemaDialect is implemented as databases and schemas are the same thing in
+// MySQL, without owners.
+var _ schemaDialect = mysqlDialect{}
+
+func (mysqlDialect) ReadSchema(ctx context.Context, db dbQueryer, name string) (*dbSchema, error) {
+	names, err := queryStrings(ctx, db, "SELECT SCHEMA_NAME FROM information_schema.SCHEMATA WHERE SCHEMA_NAME = ?", name)
+	if err != nil || len(names) == 0 {
+		return nil, err
+	}
+	return &dbSchema{Name: name}, nil
+}
+
+func (d mysqlDialect) CreateSchema(s dbSchema) ([]string, error) {
+	if s.Owner != nil {
+		return nil, fmt.Errorf("schema owners are not supported by MySQL")
+	}
+	return []string{"CREATE SCHEMA " + d.QuoteIdentifier(s.Name)}, nil
+}
+
+func (d mysqlDialect) AlterSchema(prior, planned dbSchema) ([]string, error) {
+	if planned.Owner != nil {
+		return nil, fmt.Errorf("schema owners are not supported by MySQL")
+	}
+	return nil, nil
+}
+
+func (d mysqlDialect) DropSchema(name string) string {
+	return "DROP SCHEMA " + d.QuoteIdentifier(name)
+}
+
+var _ rowsDialect = mysqlDialect{}
+
+// UpsertStatement relies on the key columns being the primary key or a
+// unique index, as MySQL updates on any duplicate key.
+func (d mysqlDialect) UpsertStatement(table string, columns, keyColumns []string) string {
+	quoted, placeholders, others := upsertParts(d, columns, keyColumns)
+
+	var sets []string
+	for _, c := range others {
+		sets = append(sets, fmt.Sprintf("%s = VALUES(%s)", d.QuoteIdentifier(c), d.QuoteIdentifier(c)))
+	}
+	if len(sets) == 0 {
+		// there's no DO NOTHING
+		key := d.QuoteIdentifier(keyColumns[0])
+		sets = append(sets, fmt.Sprintf("%s = %s", key, key))
+	}
+
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s) ON DUPLICATE KEY UPDATE %s",
+		table, quoted, placeholders, strings.Join(sets, ", "))
+}
+
+var _ viewDialect = mysqlDialect{}
+
+func (mysqlDialect) ReadView(ctx context.Context, db dbQueryer, schema, name string) (*string, error) {
+	return readDefinition(ctx, db, `SELECT VIEW_DEFINITION
+FROM information_schema.VIEWS
+WHERE TABLE_NAME = ? AND TABLE_SCHEMA = COALESCE(NULLIF(?, ''), DATABASE())`, name, schema)
+}
+
+func (d mysqlDialect) CreateView(v view) []string {
+	return []string{fmt.Sprintf("CREATE OR REPLACE VIEW %s AS %s", qualifiedName(d, v.Schema, v.Name), v.Definition)}
+}
+
+func (d mysqlDialect) DropView(schema, name string) string {
+	return "DROP VIEW " + qualifiedName(d, schema, name)
+}
+
+var _ functionDialect = mysqlDialect{}
+
+// ReadFunction looks the function up by name, as MySQL can't overload
+// functions.
+func (mysqlDialect) ReadFunction(ctx context.Context, db dbQueryer, f function) (*string, error) {
+	return readDefinition(ctx, db, `SELECT ROUTINE_DEFINITION
+FROM information_schema.ROUTINES
+WHERE ROUTINE_TYPE = 'FUNCTION' AND ROUTINE_NAME = ?
+AND ROUTINE_SCHEMA = COALESCE(NULLIF(?, ''), DATABASE())`, f.Name, f.Schema)
+}
+
+// CreateFunction drops and creates the function, as MySQL can't replace
+// functions. The body includes any characteristics, such as DETERMINISTIC.
+func (d mysqlDialect) CreateFunction(f function) ([]string, error) {
+	if f.Language != nil {
+		return nil, fmt.Errorf("function languages are not supported by MySQL")
+	}
+
+	name := qualifiedName(d, f.Schema, f.Name)
+	return []string{
+		"DROP FUNCTION IF EXISTS " + name,
+		fmt.Sprintf("CREATE FUNCTION %s(%s) RETURNS %s %s", name, f.Arguments, f.Returns, f.Body),
+	}, nil
+}
+
+func (d mysqlDialect) DropFunction(f function) string {
+	return "DROP FUNCTION " + qualifiedName(d, f.Schema, f.Name)
+}
+
+var _ serverInfoDialect = mysqlDialect{}
+
+func (d mysqlDialect) ReadServerInfo(ctx context.Context, db dbQueryer) (*serverInfo, error) {
+	var info serverInfo
+	err := scanServerInfo(ctx, db, d.VersionQuery(), &info.Version)
+	if err != nil {
+		return nil, err
+	}
+	err = scanServerInfo(ctx, db, "SELECT DATABASE(), CURRENT_USER()", &info.Database, &info.User)
+	if err != nil {
+		return nil, err
+	}
+	describeMySQL(&info)
+	return &info, nil
+}
+
+// describeMySQL tells MariaDB apart from MySQL by its version string. DDL
+// statements commit implicitly on both.
+func describeMySQL(info *serverInfo) {
+	info.Major, info.Minor = parseVersion(info.Version)
+	info.AdvisoryLocks = true
+	info.IfNotExists = true
+
+	info.Product = productMySQL
+	if strings.Contains(info.Version, "MariaDB") {
+		info.Product = productMariaDB
+	}
+}
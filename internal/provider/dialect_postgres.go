@@ -0,0 +1,756 @@
+package provider
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"hash/fnv"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/stdlib"
+)
+
+// postgresDialect is used for PostgreSQL and compatible servers, such as
+// CockroachDB.
+type postgresDialect struct{}
+
+var _ Dialect = postgresDialect{}
+
+func (postgresDialect) Driver() driverName {
+	return driverPgx
+}
+
+func (postgresDialect) Schemes() []string {
+	return []string{"postgres", "postgresql"}
+}
+
+func (postgresDialect) DataSourceName(url string) string {
+	return url
+}
+
+func (postgresDialect) ConnectionURL(c connectionConfig) (string, error) {
+	u := &url.URL{
+		Scheme:   "postgres",
+		User:     c.userinfo(),
+		Host:     c.hostPort(),
+		RawQuery: c.query().Encode(),
+	}
+	if c.Database != "" {
+		u.Path = "/" + c.Database
+	}
+	return u.String(), nil
+}
+
+func (postgresDialect) Connector(dsn string, opts connectorOptions) (driver.Connector, error) {
+	cfg, err := pgx.ParseConfig(dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.TLS != nil {
+		cfg.TLSConfig = withServerName(opts.TLS, cfg.Host)
+		// fallbacks would allow connecting without TLS, ie. sslmode=prefer
+		cfg.Fallbacks = nil
+	}
+
+	if opts.Dialer != nil {
+		cfg.DialFunc = opts.Dialer.DialContext
+		// resolve the host through the dialer rather than locally
+		cfg.LookupFunc = func(ctx context.Context, host string) ([]string, error) {
+			return []string{host}, nil
+		}
+	}
+
+	var connOpts []stdlib.OptionOpenDB
+	if opts.Password != nil {
+		connOpts = append(connOpts, stdlib.OptionBeforeConnect(func(ctx context.Context, cfg *pgx.ConnConfig) error {
+			password, err := opts.Password(ctx)
+			if err != nil {
+				return err
+			}
+			cfg.Password = password
+			return nil
+		}))
+	}
+	if len(opts.InitStatements) > 0 {
+		connOpts = append(connOpts, stdlib.OptionAfterConnect(func(ctx context.Context, conn *pgx.Conn) error {
+			for _, stmt := range opts.InitStatements {
+				_, err := conn.Exec(ctx, stmt)
+				if err != nil {
+					return fmt.Errorf("unable to run init statement %q: %w", stmt, err)
+				}
+			}
+			return nil
+		}))
+	}
+
+	return stdlib.GetConnector(*cfg, connOpts...), nil
+}
+
+func (postgresDialect) QuoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+func (postgresDialect) QuoteString(s string) string {
+	// backslashes are literal with standard_conforming_strings, the default
+	// since PostgreSQL 9.1
+	return quoteString(s)
+}
+
+func (postgresDialect) Placeholder(n int) string {
+	return fmt.Sprintf("$%d", n)
+}
+
+func (postgresDialect) ColumnType(colType *sql.ColumnType) (tftypes.Type, reflect.Type, bool) {
+	switch colType.DatabaseTypeName() {
+	// 790 is the oid of money
+	case "MONEY", "790":
+		// TODO: add diags about converting to numeric?
+		return tftypes.String, reflect.TypeOf((*sql.NullString)(nil)).Elem(), true
+	case "TIMESTAMPTZ", "TIMESTAMP", "DATE":
+		return tftypes.String, reflect.TypeOf((*sql.NullTime)(nil)).Elem(), true
+	}
+
+	return nil, nil, false
+}
+
+func (postgresDialect) BeginReadOnly(ctx context.Context, db dbTxQueryer) (*sql.Tx, error) {
+	return db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+}
+
+func (postgresDialect) AcquireLock(ctx context.Context, conn *sql.Conn, name string) (func(context.Context) error, error) {
+	// advisory locks are keyed by a number rather than a name
+	h := fnv.New64a()
+	h.Write([]byte(name))
+	key := int64(h.Sum64())
+
+	_, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", key)
+	if err != nil {
+		return nil, fmt.Errorf("unable to acquire lock %q: %w", name, err)
+	}
+
+	return func(ctx context.Context) error {
+		_, err := conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", key)
+		return err
+	}, nil
+}
+
+func (postgresDialect) VersionQuery() string {
+	return "SELECT version()"
+}
+
+func (postgresDialect) SplitStatements(script string) []string {
+	// the simple protocol allows multiple statements in a single exec
+	return []string{script}
+}
+
+var _ tableDialect = postgresDialect{}
+
+func (postgresDialect) CurrentSchema() string {
+	return "current_schema()"
+}
+
+var postgresTypeAliases = map[string]string{
+	"int":         "integer",
+	"int4":        "integer",
+	"int8":        "bigint",
+	"int2":        "smallint",
+	"serial4":     "serial",
+	"serial8":     "bigserial",
+	"serial2":     "smallserial",
+	"varchar":     "character varying",
+	"char":        "character",
+	"bpchar":      "character",
+	"varbit":      "bit varying",
+	"bool":        "boolean",
+	"float":       "double precision",
+	"float8":      "double precision",
+	"float4":      "real",
+	"decimal":     "numeric",
+	"timestamptz": "timestamp with time zone",
+	"timestamp":   "timestamp without time zone",
+	"timetz":      "time with time zone",
+	"time":        "time without time zone",
+}
+
+var postgresArrayBounds = regexp.MustCompile(`\[\d*\]`)
+
+func (postgresDialect) NormalizeColumnType(colType string) string {
+	base, params, rest := splitColumnType(colType)
+
+	// the precision of time types comes before the time zone, ie.
+	// timestamp(3) with time zone
+	if strings.HasPrefix(rest, "with") {
+		base, rest = base+" "+rest, ""
+	}
+	if alias, ok := postgresTypeAliases[base]; ok {
+		base = alias
+	}
+	if base == "character" && params == "" {
+		params = "1"
+	}
+	if strings.HasPrefix(rest, "[") {
+		// array bounds are not enforced, so only the dimensions matter
+		rest = postgresArrayBounds.ReplaceAllString(rest, "[]")
+	}
+
+	return joinColumnType(base, params, rest)
+}
+
+// postgresDefaultCast matches the casts PostgreSQL adds to the literals of
+// defaults, ie. 'x'::character varying.
+var postgresDefaultCast = regexp.MustCompile(`(::[a-z_ ]+(\([0-9, ]*\))?(\[\])*)+$`)
+
+func (postgresDialect) NormalizeDefault(dflt string) string {
+	dflt = foldDefault(dflt)
+	for {
+		next := trimParens(postgresDefaultCast.ReplaceAllString(dflt, ""))
+		if next == dflt {
+			break
+		}
+		dflt = next
+	}
+
+	// negative numbers are reported as quoted literals, ie. '-1'::integer
+	if value, ok := unquoteDefault(dflt); ok {
+		if _, err := strconv.ParseFloat(value, 64); err == nil {
+			return value
+		}
+	}
+	return dflt
+}
+
+func (postgresDialect) ReadTables(ctx context.Context, db dbQueryer, schema string) ([]string, error) {
+	return queryStrings(ctx, db, `SELECT table_name
+FROM information_schema.tables
+WHERE table_schema = $1 AND table_type = 'BASE TABLE'
+ORDER BY table_name`, schema)
+}
+
+func (d postgresDialect) ReadColumns(ctx context.Context, db dbQueryer, schema, name string) ([]tableColumn, error) {
+	rows, err := db.QueryContext(ctx, `SELECT column_name, data_type, udt_name, character_maximum_length,
+	numeric_precision, numeric_scale, datetime_precision, is_nullable, column_default
+FROM information_schema.columns
+WHERE table_schema = $1 AND table_name = $2
+ORDER BY ordinal_position`, schema, name)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var columns []tableColumn
+	for rows.Next() {
+		var (
+			c                                     tableColumn
+			dataType, udtName, nullable           string
+			length, precision, scale, dtPrecision sql.NullInt64
+			dflt                                  sql.NullString
+		)
+		err = rows.Scan(&c.Name, &dataType, &udtName, &length, &precision, &scale, &dtPrecision, &nullable, &dflt)
+		if err != nil {
+			return nil, err
+		}
+
+		c.Type = dataType
+		switch {
+		case dataType == "ARRAY":
+			c.Type = d.NormalizeColumnType(strings.TrimPrefix(udtName, "_")) + "[]"
+		case dataType == "USER-DEFINED":
+			c.Type = udtName
+		case length.Valid:
+			c.Type = fmt.Sprintf("%s(%d)", dataType, length.Int64)
+		case dataType == "numeric" && precision.Valid:
+			c.Type = fmt.Sprintf("numeric(%d,%d)", precision.Int64, scale.Int64)
+		case strings.HasPrefix(dataType, "time") && dtPrecision.Valid && dtPrecision.Int64 != 6:
+			// the precision comes before any time zone, ie. time(3) with time zone
+			base, zone, _ := strings.Cut(dataType, " ")
+			c.Type = strings.TrimSpace(fmt.Sprintf("%s(%d) %s", base, dtPrecision.Int64, zone))
+		}
+
+		c.Nullable = nullable == "YES"
+		if dflt.Valid {
+			c.Default = &dflt.String
+		}
+
+		// serial types are integers defaulting to the next value of a sequence
+		if c.Default != nil && strings.HasPrefix(*c.Default, "nextval(") {
+			serial := map[string]string{"integer": "serial", "bigint": "bigserial", "smallint": "smallserial"}[c.Type]
+			if serial != "" {
+				c.Type, c.Default = serial, nil
+			}
+		}
+
+		columns = append(columns, c)
+	}
+
+	return columns, rows.Err()
+}
+
+func (postgresDialect) ReadIndexes(ctx context.Context, db dbQueryer, schema, name string) ([]tableIndex, error) {
+	rows, err := db.QueryContext(ctx, `SELECT i.relname, ix.indisunique, a.attname
+FROM pg_catalog.pg_index ix
+JOIN pg_catalog.pg_class i ON i.oid = ix.indexrelid
+JOIN pg_catalog.pg_class t ON t.oid = ix.indrelid
+JOIN pg_catalog.pg_namespace n ON n.oid = t.relnamespace
+CROSS JOIN LATERAL unnest(ix.indkey) WITH ORDINALITY AS k(attnum, position)
+JOIN pg_catalog.pg_attribute a ON a.attrelid = t.oid AND a.attnum = k.attnum
+WHERE n.nspname = $1 AND t.relname = $2 AND NOT ix.indisprimary
+	AND NOT EXISTS (SELECT 1 FROM pg_catalog.pg_constraint c WHERE c.conindid = ix.indexrelid)
+ORDER BY i.relname, k.position`, schema, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanIndexes(rows)
+}
+
+func (d postgresDialect) AddColumn(schema, name string, c tableColumn) []string {
+	return []string{
+		fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s", qualifiedName(d, schema, name), columnDefinition(d, c)),
+	}
+}
+
+func (d postgresDialect) AlterColumn(schema, name string, prior, planned tableColumn) []string {
+	alter := fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s", qualifiedName(d, schema, name), d.QuoteIdentifier(planned.Name))
+
+	var stmts []string
+	if !sameColumnType(d, prior.Type, planned.Type) {
+		stmts = append(stmts, alter+" TYPE "+planned.Type)
+	}
+	if prior.Nullable != planned.Nullable {
+		if planned.Nullable {
+			stmts = append(stmts, alter+" DROP NOT NULL")
+		} else {
+			stmts = append(stmts, alter+" SET NOT NULL")
+		}
+	}
+	if !equalStringPtr(prior.Default, planned.Default) {
+		if planned.Default == nil {
+			stmts = append(stmts, alter+" DROP DEFAULT")
+		} else {
+			stmts = append(stmts, alter+" SET DEFAULT "+*planned.Default)
+		}
+	}
+	return stmts
+}
+
+func (d postgresDialect) DropColumn(schema, name string, c tableColumn) []string {
+	return []string{
+		fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s", qualifiedName(d, schema, name), d.QuoteIdentifier(c.Name)),
+	}
+}
+
+func (d postgresDialect) DropIndex(schema, name string, index tableIndex) string {
+	// indexes are always in the schema of their table
+	return "DROP INDEX " + qualifiedName(d, schema, index.Name)
+}
+
+var _ roleDialect = postgresDialect{}
+
+func (postgresDialect) ReadRole(ctx context.Context, db dbQueryer, name string) (*role, error) {
+	rows, err := db.QueryContext(ctx, "SELECT rolcanlogin, rolconnlimit FROM pg_catalog.pg_roles WHERE rolname = $1", name)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return nil, rows.Err()
+	}
+
+	r := &role{Name: name}
+	err = rows.Scan(&r.Login, &r.ConnectionLimit)
+	if err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	r.Roles, err = queryStrings(ctx, db, `SELECT r.rolname
+FROM pg_catalog.pg_auth_members m
+JOIN pg_catalog.pg_roles r ON r.oid = m.roleid
+JOIN pg_catalog.pg_roles u ON u.oid = m.member
+WHERE u.rolname = $1
+ORDER BY r.rolname`, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (d postgresDialect) roleOptions(r role) string {
+	opts := "NOLOGIN"
+	if r.Login {
+		opts = "LOGIN"
+	}
+	return fmt.Sprintf("%s CONNECTION LIMIT %d", opts, r.ConnectionLimit)
+}
+
+func (d postgresDialect) rolePassword(r role) string {
+	if r.Password == nil {
+		return "PASSWORD NULL"
+	}
+	return "PASSWORD " + d.QuoteString(*r.Password)
+}
+
+func (d postgresDialect) CreateRole(r role) ([]string, error) {
+	create := fmt.Sprintf("CREATE ROLE %s WITH %s", d.QuoteIdentifier(r.Name), d.roleOptions(r))
+	if r.Password != nil {
+		create += " " + d.rolePassword(r)
+	}
+
+	stmts := []string{create}
+	for _, member := range r.Roles {
+		stmts = append(stmts, fmt.Sprintf("GRANT %s TO %s", d.QuoteIdentifier(member), d.QuoteIdentifier(r.Name)))
+	}
+	return stmts, nil
+}
+
+func (d postgresDialect) AlterRole(prior, planned role) ([]string, error) {
+	name := d.QuoteIdentifier(planned.Name)
+
+	var stmts []string
+	if prior.Login != planned.Login || prior.ConnectionLimit != planned.ConnectionLimit {
+		stmts = append(stmts, fmt.Sprintf("ALTER ROLE %s WITH %s", name, d.roleOptions(planned)))
+	}
+	if !equalStringPtr(prior.Password, planned.Password) {
+		stmts = append(stmts, fmt.Sprintf("ALTER ROLE %s WITH %s", name, d.rolePassword(planned)))
+	}
+
+	added, removed := diffStrings(prior.Roles, planned.Roles)
+	for _, member := range removed {
+		stmts = append(stmts, fmt.Sprintf("REVOKE %s FROM %s", d.QuoteIdentifier(member), name))
+	}
+	for _, member := range added {
+		stmts = append(stmts, fmt.Sprintf("GRANT %s TO %s", d.QuoteIdentifier(member), name))
+	}
+	return stmts, nil
+}
+
+func (d postgresDialect) DropRole(r role) []string {
+	return []string{"DROP ROLE " + d.QuoteIdentifier(r.Name)}
+}
+
+func (postgresDialect) RoleRequiresReplace(prior, planned role) []string {
+	return nil
+}
+
+var _ grantDialect = postgresDialect{}
+
+func (postgresDialect) ReadGrants(ctx context.Context, db dbQueryer, role, objectType, schema string) (map[string]objectPrivileges, error) {
+	var rows *sql.Rows
+	var err error
+	switch objectType {
+	case grantObjectTable:
+		rows, err = db.QueryContext(ctx, `SELECT c.relname, a.privilege_type, a.is_grantable
+FROM pg_catalog.pg_class c
+JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+CROSS JOIN LATERAL aclexplode(c.relacl) a
+JOIN pg_catalog.pg_roles r ON r.oid = a.grantee
+WHERE r.rolname = $1
+AND n.nspname = COALESCE(NULLIF($2, ''), current_schema())
+AND c.relkind IN ('r', 'v', 'm', 'p', 'f')`, role, schema)
+	case grantObjectSchema:
+		rows, err = db.QueryContext(ctx, `SELECT n.nspname, a.privilege_type, a.is_grantable
+FROM pg_catalog.pg_namespace n
+CROSS JOIN LATERAL aclexplode(n.nspacl) a
+JOIN pg_catalog.pg_roles r ON r.oid = a.grantee
+WHERE r.rolname = $1`, role)
+	default:
+		return nil, fmt.Errorf("unsupported object type %q", objectType)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return scanGrants(rows)
+}
+
+func (d postgresDialect) GrantTarget(objectType, schema, object string) string {
+	if objectType == grantObjectSchema {
+		return "SCHEMA " + d.QuoteIdentifier(object)
+	}
+	return "TABLE " + qualifiedName(d, schema, object)
+}
+
+func (d postgresDialect) Grantee(role string) string {
+	return d.QuoteIdentifier(role)
+}
+
+func (postgresDialect) RevokeStatement(privileges []string, target, grantee string, grantable bool) string {
+	return fmt.Sprintf("REVOKE %s ON %s FROM %s", strings.Join(privileges, ", "), target, grantee)
+}
+
+func (postgresDialect) RevokeGrantOptionStatement(privileges []string, target, grantee string) string {
+	return fmt.Sprintf("REVOKE GRANT OPTION FOR %s ON %s FROM %s", strings.Join(privileges, ", "), target, grantee)
+}
+
+func (postgresDialect) AllPrivileges(objectType string) []string {
+	switch objectType {
+	case grantObjectTable:
+		return []string{"DELETE", "INSERT", "REFERENCES", "SELECT", "TRIGGER", "TRUNCATE", "UPDATE"}
+	case grantObjectSchema:
+		return []string{"CREATE", "USAGE"}
+	}
+	return nil
+}
+
+var _ databaseDialect = postgresDialect{}
+
+func (postgresDialect) ReadDatabase(ctx context.Context, db dbQueryer, name string) (*database, error) {
+	rows, err := db.QueryContext(ctx, `SELECT pg_catalog.pg_get_userbyid(datdba), pg_catalog.pg_encoding_to_char(encoding), datcollate
+FROM pg_catalog.pg_database
+WHERE datname = $1`, name)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return nil, rows.Err()
+	}
+
+	d := &database{Name: name}
+	err = rows.Scan(&d.Owner, &d.Encoding, &d.Collation)
+	if err != nil {
+		return nil, err
+	}
+	return d, rows.Err()
+}
+
+// CreateDatabase copies template0 when setting the encoding or collation, as
+// they must match those of template1 otherwise.
+func (d postgresDialect) CreateDatabase(db database) ([]string, error) {
+	var opts []string
+	if db.Owner != nil {
+		opts = append(opts, "OWNER "+d.QuoteIdentifier(*db.Owner))
+	}
+	if db.Encoding != nil || db.Collation != nil {
+		opts = append(opts, "TEMPLATE template0")
+	}
+	if db.Encoding != nil {
+		opts = append(opts, "ENCODING "+d.QuoteString(*db.Encoding))
+	}
+	if db.Collation != nil {
+		opts = append(opts, "LC_COLLATE "+d.QuoteString(*db.Collation))
+	}
+
+	create := "CREATE DATABASE " + d.QuoteIdentifier(db.Name)
+	if len(opts) > 0 {
+		create += " WITH " + strings.Join(opts, " ")
+	}
+	return []string{create}, nil
+}
+
+func (d postgresDialect) AlterDatabase(prior, planned database) ([]string, error) {
+	var stmts []string
+	if planned.Owner != nil && !equalStringPtr(prior.Owner, planned.Owner) {
+		stmts = append(stmts, fmt.Sprintf("ALTER DATABASE %s OWNER TO %s", d.QuoteIdentifier(planned.Name), d.QuoteIdentifier(*planned.Owner)))
+	}
+	return stmts, nil
+}
+
+func (d postgresDialect) DropDatabase(name string) string {
+	return "DROP DATABASE " + d.QuoteIdentifier(name)
+}
+
+// DatabaseRequiresReplace replaces databases changing encoding or collation,
+// which PostgreSQL can't alter.
+func (postgresDialect) DatabaseRequiresReplace(prior, planned database) []string {
+	var attrs []string
+	if planned.Encoding != nil && !equalFoldStringPtr(prior.Encoding, planned.Encoding) {
+		attrs = append(attrs, "encoding")
+	}
+	if planned.Collation != nil && !equalFoldStringPtr(prior.Collation, planned.Collation) {
+		attrs = append(attrs, "collation")
+	}
+	return attrs
+}
+
+var _ schemaDialect = postgresDialect{}
+
+func (postgresDialect) ReadSchema(ctx context.Context, db dbQueryer, name string) (*dbSchema, error) {
+	owners, err := queryStrings(ctx, db, "SELECT pg_catalog.pg_get_userbyid(nspowner) FROM pg_catalog.pg_namespace WHERE nspname = $1", name)
+	if err != nil || len(owners) == 0 {
+		return nil, err
+	}
+	return &dbSchema{Name: name, Owner: &owners[0]}, nil
+}
+
+func (d postgresDialect) CreateSchema(s dbSchema) ([]string, error) {
+	create := "CREATE SCHEMA " + d.QuoteIdentifier(s.Name)
+	if s.Owner != nil {
+		create += " AUTHORIZATION " + d.QuoteIdentifier(*s.Owner)
+	}
+	return []string{create}, nil
+}
+
+func (d postgresDialect) AlterSchema(prior, planned dbSchema) ([]string, error) {
+	var stmts []string
+	if planned.Owner != nil && !equalStringPtr(prior.Owner, planned.Owner) {
+		stmts = append(stmts, fmt.Sprintf("ALTER SCHEMA %s OWNER TO %s", d.QuoteIdentifier(planned.Name), d.QuoteIdentifier(*planned.Owner)))
+	}
+	return stmts, nil
+}
+
+func (d postgresDialect) DropSchema(name string) string {
+	return "DROP SCHEMA " + d.QuoteIdentifier(name)
+}
+
+var _ rowsDialect = postgresDialect{}
+
+func (d postgresDialect) UpsertStatement(table string, columns, keyColumns []string) string {
+	quoted, placeholders, others := upsertParts(d, columns, keyColumns)
+
+	action := "NOTHING"
+	if len(others) > 0 {
+		sets := make([]string, len(others))
+		for i, c := range others {
+			sets[i] = fmt.Sprintf("%s = EXCLUDED.%s", d.QuoteIdentifier(c), d.QuoteIdentifier(c))
+		}
+		action = "UPDATE SET " + strings.Join(sets, ", ")
+	}
+
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s) ON CONFLICT (%s) DO %s",
+		table, quoted, placeholders, quoteIdentifiers(d, keyColumns), action)
+}
+
+var _ viewDialect = postgresDialect{}
+
+func (postgresDialect) ReadView(ctx context.Context, db dbQueryer, schema, name string) (*string, error) {
+	return readDefinition(ctx, db, `SELECT pg_catalog.pg_get_viewdef(c.oid)
+FROM pg_catalog.pg_class c
+JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+WHERE c.relkind = 'v' AND c.relname = $1
+AND n.nspname = COALESCE(NULLIF($2, ''), current_schema())`, name, schema)
+}
+
+// CreateView replaces the view in place, which PostgreSQL only allows when
+// adding columns at the end.
+func (d postgresDialect) CreateView(v view) []string {
+	return []string{fmt.Sprintf("CREATE OR REPLACE VIEW %s AS %s", qualifiedName(d, v.Schema, v.Name), v.Definition)}
+}
+
+func (d postgresDialect) DropView(schema, name string) string {
+	return "DROP VIEW " + qualifiedName(d, schema, name)
+}
+
+var _ functionDialect = postgresDialect{}
+
+// ReadFunction looks the function up by its signature, as functions can be
+// overloaded with different arguments.
+func (postgresDialect) ReadFunction(ctx context.Context, db dbQueryer, f function) (*string, error) {
+	return readDefinition(ctx, db, `SELECT pg_catalog.pg_get_functiondef(p.oid)
+FROM pg_catalog.pg_proc p
+WHERE p.oid = to_regprocedure(
+	quote_ident(COALESCE(NULLIF($1, ''), current_schema())) || '.' || quote_ident($2) || '(' || $3 || ')')`,
+		f.Schema, f.Name, postgresArgumentTypes(f.Arguments))
+}
+
+// postgresArgumentModes are the modes an argument can be declared with, OUT
+// arguments are not part of the signature.
+var postgresArgumentModes = map[string]bool{
+	"in":       true,
+	"out":      true,
+	"inout":    true,
+	"variadic": true,
+}
+
+// postgresTypeContinuations are the words that continue a type name rather
+// than following an argument name, ie. double precision.
+var postgresTypeContinuations = map[string]bool{
+	"precision": true,
+	"varying":   true,
+	"with":      true,
+	"without":   true,
+}
+
+var postgresArgumentDefault = regexp.MustCompile(`(?i)\s+default\s|\s*=`)
+
+// postgresArgumentTypes returns the types of the arguments that identify a
+// function, without their modes, names and defaults, ie. "a int, b text DEFAULT 'x'"
+// is "int, text".
+func postgresArgumentTypes(arguments string) string {
+	var types []string
+	for _, arg := range splitArguments(arguments) {
+		if loc := postgresArgumentDefault.FindStringIndex(arg); loc != nil {
+			arg = arg[:loc[0]]
+		}
+
+		words := strings.Fields(arg)
+		if len(words) > 1 && postgresArgumentModes[strings.ToLower(words[0])] {
+			if strings.EqualFold(words[0], "out") {
+				continue
+			}
+			words = words[1:]
+		}
+
+		switch {
+		case len(words) > 1 && strings.HasPrefix(words[0], `"`):
+			// a quoted name, which can contain spaces
+			rest := strings.Join(words, " ")
+			if end := strings.Index(rest[1:], `"`); end >= 0 {
+				words = strings.Fields(rest[end+2:])
+			}
+		case len(words) > 1 && !postgresTypeContinuations[strings.ToLower(words[1])] &&
+			!strings.ContainsAny(words[0], "([") && !strings.HasPrefix(words[1], "(") && !strings.HasPrefix(words[1], "["):
+			words = words[1:]
+		}
+		types = append(types, strings.Join(words, " "))
+	}
+	return strings.Join(types, ", ")
+}
+
+// CreateFunction defaults to SQL functions, the body is dollar quoted.
+func (d postgresDialect) CreateFunction(f function) ([]string, error) {
+	language := "sql"
+	if f.Language != nil {
+		language = *f.Language
+	}
+	return []string{fmt.Sprintf("CREATE OR REPLACE FUNCTION %s(%s) RETURNS %s LANGUAGE %s AS %s",
+		qualifiedName(d, f.Schema, f.Name), f.Arguments, f.Returns, language, dollarQuote(f.Body))}, nil
+}
+
+func (d postgresDialect) DropFunction(f function) string {
+	return fmt.Sprintf("DROP FUNCTION %s(%s)", qualifiedName(d, f.Schema, f.Name), f.Arguments)
+}
+
+var _ serverInfoDialect = postgresDialect{}
+
+func (d postgresDialect) ReadServerInfo(ctx context.Context, db dbQueryer) (*serverInfo, error) {
+	var info serverInfo
+	err := scanServerInfo(ctx, db, d.VersionQuery(), &info.Version)
+	if err != nil {
+		return nil, err
+	}
+	err = scanServerInfo(ctx, db, "SELECT current_database(), current_user", &info.Database, &info.User)
+	if err != nil {
+		return nil, err
+	}
+	describePostgres(&info)
+	return &info, nil
+}
+
+// describePostgres tells CockroachDB, which also uses the pgx driver, apart
+// from PostgreSQL by its version string.
+func describePostgres(info *serverInfo) {
+	info.Major, info.Minor = parseVersion(info.Version)
+	info.IfNotExists = true
+
+	if strings.Contains(info.Version, "CockroachDB") {
+		// schema changes in transactions are not atomic, and advisory lock
+		// functions are accepted but do not lock
+		info.Product = productCockroachDB
+		return
+	}
+
+	info.Product = productPostgres
+	info.TransactionalDDL = true
+	info.AdvisoryLocks = true
+}
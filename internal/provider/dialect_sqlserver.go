@@ -0,0 +1,889 @@
+package provider
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	mssql "github.com/microsoft/go-mssqldb"
+	"github.com/microsoft/go-mssqldb/azuread"
+	"github.com/microsoft/go-mssqldb/msdsn"
+)
+
+// sqlServerDialect is used for Microsoft SQL Server.
+type sqlServerDialect struct{}
+
+var _ Dialect = sqlServerDialect{}
+
+func (sqlServerDialect) Driver() driverName {
+	return driverSQLServer
+}
+
+func (sqlServerDialect) Schemes() []string {
+	return []string{"sqlserver"}
+}
+
+func (sqlServerDialect) DataSourceName(url string) string {
+	return url
+}
+
+func (d sqlServerDialect) ConnectionURL(c connectionConfig) (string, error) {
+	return sqlServerConnectionURL("sqlserver", c), nil
+}
+
+func sqlServerConnectionURL(scheme string, c connectionConfig) string {
+	query := c.query()
+	if c.Database != "" {
+		query.Set("database", c.Database)
+	}
+	u := &url.URL{
+		Scheme:   scheme,
+		User:     c.userinfo(),
+		Host:     c.hostPort(),
+		RawQuery: query.Encode(),
+	}
+	return u.String()
+}
+
+func (sqlServerDialect) Connector(dsn string, opts connectorOptions) (driver.Connector, error) {
+	cfg, err := msdsn.Parse(dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.TLS != nil {
+		if len(opts.TLS.Certificates) > 0 {
+			return nil, fmt.Errorf("client certificates are not supported by SQL Server")
+		}
+
+		// the parsed config already accounts for hostNameInCertificate
+		host := cfg.Host
+		if cfg.TLSConfig != nil && cfg.TLSConfig.ServerName != "" {
+			host = cfg.TLSConfig.ServerName
+		}
+		cfg.TLSConfig = withServerName(opts.TLS, host)
+		if cfg.Encryption != msdsn.EncryptionStrict {
+			cfg.Encryption = msdsn.EncryptionRequired
+		}
+	}
+
+	switch {
+	case opts.Password == nil:
+		return withMSSQLOptions(mssql.NewConnectorConfig(cfg), opts), nil
+
+	case cfg.User == "":
+		// without a login the password is an Azure AD access token
+		c, err := mssql.NewSecurityTokenConnector(cfg, opts.Password)
+		if err != nil {
+			return nil, err
+		}
+		return withMSSQLOptions(c, opts), nil
+
+	default:
+		return &passwordConnector{
+			driver:   mssql.NewConnectorConfig(cfg).Driver(),
+			password: opts.Password,
+			connector: func(password string) (driver.Connector, error) {
+				cfg := cfg
+				cfg.Password = password
+				return withMSSQLOptions(mssql.NewConnectorConfig(cfg), opts), nil
+			},
+		}, nil
+	}
+}
+
+// withMSSQLOptions sets the options of the connector that are the same for
+// sqlserver and azuresql.
+func withMSSQLOptions(c *mssql.Connector, opts connectorOptions) driver.Connector {
+	if opts.Dialer != nil {
+		c.Dialer = opts.Dialer
+	}
+	if len(opts.InitStatements) == 0 {
+		return c
+	}
+
+	// the driver only runs SessionInitSQL when a pooled connection is reset
+	// for reuse, which discards the session settings, so new connections
+	// need the wrapper as well
+	c.SessionInitSQL = strings.Join(opts.InitStatements, "\n")
+	return &initConnector{Connector: c, statements: opts.InitStatements}
+}
+
+func (sqlServerDialect) QuoteIdentifier(name string) string {
+	return "[" + strings.ReplaceAll(name, "]", "]]") + "]"
+}
+
+func (sqlServerDialect) QuoteString(s string) string {
+	return "N" + quoteString(s)
+}
+
+func (sqlServerDialect) Placeholder(n int) string {
+	return fmt.Sprintf("@p%d", n)
+}
+
+func (sqlServerDialect) ColumnType(colType *sql.ColumnType) (tftypes.Type, reflect.Type, bool) {
+	switch colType.DatabaseTypeName() {
+	case "UNIQUEIDENTIFIER":
+		return tftypes.String, reflect.TypeOf((*sqlServerUniqueIdentifier)(nil)).Elem(), true
+	case "DECIMAL", "MONEY", "SMALLMONEY":
+		// TODO: add diags about converting to numeric?
+		return tftypes.String, reflect.TypeOf((*sql.NullString)(nil)).Elem(), true
+	}
+
+	return nil, nil, false
+}
+
+func (sqlServerDialect) BeginReadOnly(ctx context.Context, db dbTxQueryer) (*sql.Tx, error) {
+	// go-mssqldb rejects the read only flag and T-SQL has no read only
+	// transactions, so writes are only prevented by the rollback.
+	return db.BeginTx(ctx, nil)
+}
+
+func (sqlServerDialect) AcquireLock(ctx context.Context, conn *sql.Conn, name string) (func(context.Context) error, error) {
+	// sp_getapplock returns a negative status if the lock was not granted
+	var status int
+	err := conn.QueryRowContext(ctx, `
+DECLARE @status int;
+EXEC @status = sp_getapplock @Resource = @p1, @LockMode = 'Exclusive', @LockOwner = 'Session', @LockTimeout = -1;
+SELECT @status;`, name).Scan(&status)
+	if err != nil {
+		return nil, fmt.Errorf("unable to acquire lock %q: %w", name, err)
+	}
+	if status < 0 {
+		return nil, fmt.Errorf("unable to acquire lock %q: status %d", name, status)
+	}
+
+	return func(ctx context.Context) error {
+		_, err := conn.ExecContext(ctx, "EXEC sp_releaseapplock @Resource = @p1, @LockOwner = 'Session'", name)
+		return err
+	}, nil
+}
+
+func (sqlServerDialect) VersionQuery() string {
+	return "SELECT @@VERSION"
+}
+
+// SplitStatements splits the script on GO lines, which are not T-SQL but the
+// batch separator of the SQL Server tools. GO lines within string literals or
+// block comments are part of the batch.
+func (sqlServerDialect) SplitStatements(script string) []string {
+	var batches []string
+	var batch strings.Builder
+
+	flush := func() {
+		if s := strings.TrimSpace(batch.String()); s != "" {
+			batches = append(batches, s)
+		}
+		batch.Reset()
+	}
+
+	var quoted, commented bool
+	scanner := bufio.NewScanner(strings.NewReader(script))
+	scanner.Buffer(nil, len(script)+1)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !quoted && !commented && strings.EqualFold(strings.TrimSpace(line), "GO") {
+			flush()
+			continue
+		}
+		quoted, commented = scanSQLServerLine(line, quoted, commented)
+		batch.WriteString(line)
+		batch.WriteString("\n")
+	}
+	flush()
+
+	return batches
+}
+
+// scanSQLServerLine returns whether a string literal or block comment is still
+// open at the end of the line.
+func scanSQLServerLine(line string, quoted, commented bool) (bool, bool) {
+	for i := 0; i < len(line); i++ {
+		switch {
+		case quoted:
+			// quotes within literals are doubled, which closes and reopens it
+			quoted = line[i] != '\''
+		case commented:
+			if strings.HasPrefix(line[i:], "*/") {
+				commented = false
+				i++
+			}
+		case line[i] == '\'':
+			quoted = true
+		case strings.HasPrefix(line[i:], "/*"):
+			commented = true
+			i++
+		case strings.HasPrefix(line[i:], "--"):
+			return false, false
+		}
+	}
+	return quoted, commented
+}
+
+// azureSQLDialect is used for Azure SQL Database, which is SQL Server with
+// Azure AD authentication.
+type azureSQLDialect struct {
+	sqlServerDialect
+}
+
+var _ Dialect = azureSQLDialect{}
+
+func (azureSQLDialect) Driver() driverName {
+	return driverAzureSQL
+}
+
+func (azureSQLDialect) Schemes() []string {
+	return []string{"azuresql"}
+}
+
+func (azureSQLDialect) DataSourceName(url string) string {
+	return strings.Replace(url, "azuresql://", "sqlserver://", 1)
+}
+
+func (azureSQLDialect) ConnectionURL(c connectionConfig) (string, error) {
+	return sqlServerConnectionURL("azuresql", c), nil
+}
+
+func (azureSQLDialect) Connector(dsn string, opts connectorOptions) (driver.Connector, error) {
+	if opts.TLS != nil {
+		return nil, fmt.Errorf("TLS settings are not supported for azuresql, connections are always encrypted")
+	}
+
+	if opts.Password != nil {
+		// the password is an access token, replacing the fedauth method
+		cfg, err := msdsn.Parse(dsn)
+		if err != nil {
+			return nil, err
+		}
+
+		c, err := mssql.NewSecurityTokenConnector(cfg, opts.Password)
+		if err != nil {
+			return nil, err
+		}
+		return withMSSQLOptions(c, opts), nil
+	}
+
+	c, err := azuread.NewConnector(dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	return withMSSQLOptions(c, opts), nil
+}
+
+var _ tableDialect = sqlServerDialect{}
+
+func (sqlServerDialect) CurrentSchema() string {
+	return "SCHEMA_NAME()"
+}
+
+var sqlServerTypeAliases = map[string]string{
+	"integer":                    "int",
+	"dec":                        "decimal",
+	"numeric":                    "decimal",
+	"double precision":           "float",
+	"character":                  "char",
+	"character varying":          "varchar",
+	"national character":         "nchar",
+	"national character varying": "nvarchar",
+	"rowversion":                 "timestamp",
+}
+
+// sqlServerTypeDefaults are the parameters of types when they are omitted.
+var sqlServerTypeDefaults = map[string]string{
+	"decimal":        "18,0",
+	"char":           "1",
+	"varchar":        "1",
+	"nchar":          "1",
+	"nvarchar":       "1",
+	"binary":         "1",
+	"varbinary":      "1",
+	"datetime2":      "7",
+	"datetimeoffset": "7",
+	"time":           "7",
+	"float":          "53",
+}
+
+var sqlServerIdentity = regexp.MustCompile(`(?i)\s+identity\s*(\(\s*(-?\d+)\s*,\s*(-?\d+)\s*\))?`)
+
+func (sqlServerDialect) NormalizeColumnType(colType string) string {
+	// identity is configured as part of the type, ie. int identity(1,1)
+	var identity string
+	if m := sqlServerIdentity.FindStringSubmatch(colType); m != nil {
+		identity = "identity(1,1)"
+		if m[1] != "" {
+			identity = fmt.Sprintf("identity(%s,%s)", m[2], m[3])
+		}
+		colType = strings.Replace(colType, m[0], "", 1)
+	}
+
+	base, params, rest := splitColumnType(colType)
+	if alias, ok := sqlServerTypeAliases[base]; ok {
+		base = alias
+	}
+	if params == "" {
+		params = sqlServerTypeDefaults[base]
+	}
+	if base == "decimal" && !strings.Contains(params, ",") {
+		params += ",0"
+	}
+
+	return strings.TrimSpace(joinColumnType(base, params, rest) + " " + identity)
+}
+
+func (sqlServerDialect) NormalizeDefault(dflt string) string {
+	// defaults are reported in parentheses, ie. ((0)) or ('x')
+	return trimParens(foldDefault(dflt))
+}
+
+func (sqlServerDialect) ReadTables(ctx context.Context, db dbQueryer, schema string) ([]string, error) {
+	return queryStrings(ctx, db, `SELECT TABLE_NAME
+FROM INFORMATION_SCHEMA.TABLES
+WHERE TABLE_SCHEMA = @p1 AND TABLE_TYPE = 'BASE TABLE'
+ORDER BY TABLE_NAME`, schema)
+}
+
+func (d sqlServerDialect) ReadColumns(ctx context.Context, db dbQueryer, schema, name string) ([]tableColumn, error) {
+	rows, err := db.QueryContext(ctx, `SELECT c.COLUMN_NAME, c.DATA_TYPE, c.CHARACTER_MAXIMUM_LENGTH,
+	c.NUMERIC_PRECISION, c.NUMERIC_SCALE, c.DATETIME_PRECISION, c.IS_NULLABLE, c.COLUMN_DEFAULT,
+	COLUMNPROPERTY(OBJECT_ID(@p3), c.COLUMN_NAME, 'IsIdentity'),
+	CAST(IDENT_SEED(@p3) AS bigint), CAST(IDENT_INCR(@p3) AS bigint)
+FROM INFORMATION_SCHEMA.COLUMNS c
+WHERE c.TABLE_SCHEMA = @p1 AND c.TABLE_NAME = @p2
+ORDER BY c.ORDINAL_POSITION`, schema, name, qualifiedName(d, schema, name))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var columns []tableColumn
+	for rows.Next() {
+		var (
+			c                                     tableColumn
+			dataType, nullable                    string
+			length, precision, scale, dtPrecision sql.NullInt64
+			dflt                                  sql.NullString
+			isIdentity, seed, increment           sql.NullInt64
+		)
+		err = rows.Scan(&c.Name, &dataType, &length, &precision, &scale, &dtPrecision, &nullable, &dflt, &isIdentity, &seed, &increment)
+		if err != nil {
+			return nil, err
+		}
+
+		c.Type = dataType
+		switch dataType {
+		case "char", "varchar", "nchar", "nvarchar", "binary", "varbinary":
+			if length.Int64 == -1 {
+				c.Type += "(max)"
+			} else if length.Valid {
+				c.Type += fmt.Sprintf("(%d)", length.Int64)
+			}
+		case "decimal", "numeric":
+			c.Type += fmt.Sprintf("(%d,%d)", precision.Int64, scale.Int64)
+		case "datetime2", "datetimeoffset", "time":
+			c.Type += fmt.Sprintf("(%d)", dtPrecision.Int64)
+		}
+		if isIdentity.Int64 == 1 {
+			c.Type += fmt.Sprintf(" identity(%d,%d)", seed.Int64, increment.Int64)
+		}
+
+		c.Nullable = nullable == "YES"
+		if dflt.Valid {
+			c.Default = &dflt.String
+		}
+
+		columns = append(columns, c)
+	}
+
+	return columns, rows.Err()
+}
+
+func (d sqlServerDialect) ReadIndexes(ctx context.Context, db dbQueryer, schema, name string) ([]tableIndex, error) {
+	rows, err := db.QueryContext(ctx, `SELECT i.name, i.is_unique, c.name
+FROM sys.indexes i
+JOIN sys.index_columns ic ON ic.object_id = i.object_id AND ic.index_id = i.index_id
+JOIN sys.columns c ON c.object_id = ic.object_id AND c.column_id = ic.column_id
+WHERE i.object_id = OBJECT_ID(@p1) AND i.is_primary_key = 0 AND i.is_unique_constraint = 0
+	AND ic.is_included_column = 0
+ORDER BY i.name, ic.key_ordinal`, qualifiedName(d, schema, name))
+	if err != nil {
+		return nil, err
+	}
+
+	return scanIndexes(rows)
+}
+
+func (d sqlServerDialect) AddColumn(schema, name string, c tableColumn) []string {
+	return []string{
+		fmt.Sprintf("ALTER TABLE %s ADD %s", qualifiedName(d, schema, name), columnDefinition(d, c)),
+	}
+}
+
+// dropDefault returns a batch dropping the default constraint of the column,
+// which is named by the server unless it was named explicitly.
+func (d sqlServerDialect) dropDefault(schema, name, column string) string {
+	table := quoteString(qualifiedName(d, schema, name))
+	return fmt.Sprintf(`DECLARE @name sysname = (
+	SELECT dc.name FROM sys.default_constraints dc
+	WHERE dc.parent_object_id = OBJECT_ID(N%s) AND dc.parent_column_id = COLUMNPROPERTY(OBJECT_ID(N%s), N%s, 'ColumnId')
+);
+IF @name IS NOT NULL EXEC(N'ALTER TABLE ' + N%s + N' DROP CONSTRAINT ' + QUOTENAME(@name));`,
+		table, table, quoteString(column), table)
+}
+
+func (d sqlServerDialect) AlterColumn(schema, name string, prior, planned tableColumn) []string {
+	table := qualifiedName(d, schema, name)
+
+	var stmts []string
+	if !equalStringPtr(prior.Default, planned.Default) && prior.Default != nil {
+		stmts = append(stmts, d.dropDefault(schema, name, planned.Name))
+	}
+	if !sameColumnType(d, prior.Type, planned.Type) || prior.Nullable != planned.Nullable {
+		// identity can't be altered, only the data type and nullability
+		colType := sqlServerIdentity.ReplaceAllString(planned.Type, "")
+		null := "NULL"
+		if !planned.Nullable {
+			null = "NOT NULL"
+		}
+		stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s %s %s", table, d.QuoteIdentifier(planned.Name), colType, null))
+	}
+	if !equalStringPtr(prior.Default, planned.Default) && planned.Default != nil {
+		stmts = append(stmts, fmt.Sprintf("ALTER TABLE %s ADD DEFAULT %s FOR %s", table, *planned.Default, d.QuoteIdentifier(planned.Name)))
+	}
+	return stmts
+}
+
+func (d sqlServerDialect) DropColumn(schema, name string, c tableColumn) []string {
+	var stmts []string
+	if c.Default != nil {
+		// the column can't be dropped while it is referenced by a constraint
+		stmts = append(stmts, d.dropDefault(schema, name, c.Name))
+	}
+	return append(stmts, fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s", qualifiedName(d, schema, name), d.QuoteIdentifier(c.Name)))
+}
+
+func (d sqlServerDialect) DropIndex(schema, name string, index tableIndex) string {
+	return fmt.Sprintf("DROP INDEX %s ON %s", d.QuoteIdentifier(index.Name), qualifiedName(d, schema, name))
+}
+
+var _ roleDialect = sqlServerDialect{}
+
+// ReadRole reads a database role, or a database user for a role with login.
+func (sqlServerDialect) ReadRole(ctx context.Context, db dbQueryer, name string) (*role, error) {
+	rows, err := db.QueryContext(ctx, "SELECT CASE WHEN type = 'R' THEN 0 ELSE 1 END FROM sys.database_principals WHERE name = @p1 AND type IN ('S', 'R', 'E', 'X')", name)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return nil, rows.Err()
+	}
+
+	r := &role{Name: name, ConnectionLimit: -1}
+	err = rows.Scan(&r.Login)
+	if err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	r.Roles, err = queryStrings(ctx, db, `SELECT r.name
+FROM sys.database_role_members m
+JOIN sys.database_principals r ON r.principal_id = m.role_principal_id
+JOIN sys.database_principals u ON u.principal_id = m.member_principal_id
+WHERE u.name = @p1
+ORDER BY r.name`, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (d sqlServerDialect) roleMembers(name string, roles []string, action string) []string {
+	stmts := make([]string, 0, len(roles))
+	for _, member := range roles {
+		stmts = append(stmts, fmt.Sprintf("ALTER ROLE %s %s MEMBER %s", d.QuoteIdentifier(member), action, d.QuoteIdentifier(name)))
+	}
+	return stmts
+}
+
+// CreateRole creates a database role, or a server login and its database user
+// for a role with login.
+func (d sqlServerDialect) CreateRole(r role) ([]string, error) {
+	if r.ConnectionLimit != -1 {
+		return nil, fmt.Errorf("connection limits are not supported by SQL Server")
+	}
+
+	name := d.QuoteIdentifier(r.Name)
+
+	var stmts []string
+	switch {
+	case !r.Login:
+		stmts = append(stmts, "CREATE ROLE "+name)
+	case r.Password == nil:
+		return nil, fmt.Errorf("a password is required for SQL Server logins")
+	default:
+		stmts = append(stmts,
+			fmt.Sprintf("CREATE LOGIN %s WITH PASSWORD = %s", name, d.QuoteString(*r.Password)),
+			fmt.Sprintf("CREATE USER %s FOR LOGIN %s", name, name),
+		)
+	}
+
+	return append(stmts, d.roleMembers(r.Name, r.Roles, "ADD")...), nil
+}
+
+func (d sqlServerDialect) AlterRole(prior, planned role) ([]string, error) {
+	if planned.ConnectionLimit != -1 {
+		return nil, fmt.Errorf("connection limits are not supported by SQL Server")
+	}
+
+	var stmts []string
+	if planned.Login && !equalStringPtr(prior.Password, planned.Password) {
+		if planned.Password == nil {
+			return nil, fmt.Errorf("a password is required for SQL Server logins")
+		}
+		stmts = append(stmts, fmt.Sprintf("ALTER LOGIN %s WITH PASSWORD = %s", d.QuoteIdentifier(planned.Name), d.QuoteString(*planned.Password)))
+	}
+
+	added, removed := diffStrings(prior.Roles, planned.Roles)
+	stmts = append(stmts, d.roleMembers(planned.Name, removed, "DROP")...)
+	stmts = append(stmts, d.roleMembers(planned.Name, added, "ADD")...)
+	return stmts, nil
+}
+
+func (d sqlServerDialect) DropRole(r role) []string {
+	name := d.QuoteIdentifier(r.Name)
+	if !r.Login {
+		return []string{"DROP ROLE " + name}
+	}
+	return []string{"DROP USER " + name, "DROP LOGIN " + name}
+}
+
+// RoleRequiresReplace replaces roles changing login, as roles, users and
+// logins are different kinds of principal.
+func (sqlServerDialect) RoleRequiresReplace(prior, planned role) []string {
+	if prior.Login != planned.Login {
+		return []string{"login"}
+	}
+	return nil
+}
+
+var _ roleDialect = azureSQLDialect{}
+
+// CreateRole creates a contained database user for a role with login, as
+// logins can only be created in the master database of Azure SQL Database.
+func (d azureSQLDialect) CreateRole(r role) ([]string, error) {
+	if !r.Login || r.Password == nil {
+		return d.sqlServerDialect.CreateRole(r)
+	}
+	if r.ConnectionLimit != -1 {
+		return nil, fmt.Errorf("connection limits are not supported by SQL Server")
+	}
+
+	stmts := []string{
+		fmt.Sprintf("CREATE USER %s WITH PASSWORD = %s", d.QuoteIdentifier(r.Name), d.QuoteString(*r.Password)),
+	}
+	return append(stmts, d.roleMembers(r.Name, r.Roles, "ADD")...), nil
+}
+
+func (d azureSQLDialect) AlterRole(prior, planned role) ([]string, error) {
+	if !planned.Login || equalStringPtr(prior.Password, planned.Password) {
+		return d.sqlServerDialect.AlterRole(prior, planned)
+	}
+	if planned.Password == nil {
+		return nil, fmt.Errorf("a password is required for SQL Server logins")
+	}
+
+	// the password is changed separately, as it belongs to the user
+	prior.Password = planned.Password
+	stmts, err := d.sqlServerDialect.AlterRole(prior, planned)
+	if err != nil {
+		return nil, err
+	}
+
+	alter := fmt.Sprintf("ALTER USER %s WITH PASSWORD = %s", d.QuoteIdentifier(planned.Name), d.QuoteString(*planned.Password))
+	return append([]string{alter}, stmts...), nil
+}
+
+func (d azureSQLDialect) DropRole(r role) []string {
+	if !r.Login {
+		return d.sqlServerDialect.DropRole(r)
+	}
+	return []string{"DROP USER " + d.QuoteIdentifier(r.Name)}
+}
+
+var _ grantDialect = sqlServerDialect{}
+
+func (sqlServerDialect) ReadGrants(ctx context.Context, db dbQueryer, role, objectType, schema string) (map[string]objectPrivileges, error) {
+	var rows *sql.Rows
+	var err error
+	switch objectType {
+	case grantObjectTable:
+		rows, err = db.QueryContext(ctx, `SELECT o.name, p.permission_name, CASE WHEN p.state = 'W' THEN 1 ELSE 0 END
+FROM sys.database_permissions p
+JOIN sys.objects o ON o.object_id = p.major_id
+JOIN sys.database_principals u ON u.principal_id = p.grantee_principal_id
+WHERE p.class = 1 AND p.minor_id = 0 AND p.state IN ('G', 'W')
+AND u.name = @p1
+AND SCHEMA_NAME(o.schema_id) = COALESCE(NULLIF(@p2, ''), SCHEMA_NAME())`, role, schema)
+	case grantObjectSchema:
+		rows, err = db.QueryContext(ctx, `SELECT s.name, p.permission_name, CASE WHEN p.state = 'W' THEN 1 ELSE 0 END
+FROM sys.database_permissions p
+JOIN sys.schemas s ON s.schema_id = p.major_id
+JOIN sys.database_principals u ON u.principal_id = p.grantee_principal_id
+WHERE p.class = 3 AND p.state IN ('G', 'W')
+AND u.name = @p1`, role)
+	default:
+		return nil, fmt.Errorf("unsupported object type %q", objectType)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return scanGrants(rows)
+}
+
+func (d sqlServerDialect) GrantTarget(objectType, schema, object string) string {
+	if objectType == grantObjectSchema {
+		return "SCHEMA::" + d.QuoteIdentifier(object)
+	}
+	return "OBJECT::" + qualifiedName(d, schema, object)
+}
+
+func (d sqlServerDialect) Grantee(role string) string {
+	return d.QuoteIdentifier(role)
+}
+
+// RevokeStatement cascades when revoking grantable privileges, which SQL
+// Server otherwise refuses.
+func (sqlServerDialect) RevokeStatement(privileges []string, target, grantee string, grantable bool) string {
+	stmt := fmt.Sprintf("REVOKE %s ON %s FROM %s", strings.Join(privileges, ", "), target, grantee)
+	if grantable {
+		stmt += " CASCADE"
+	}
+	return stmt
+}
+
+func (sqlServerDialect) RevokeGrantOptionStatement(privileges []string, target, grantee string) string {
+	return fmt.Sprintf("REVOKE GRANT OPTION FOR %s ON %s FROM %s CASCADE", strings.Join(privileges, ", "), target, grantee)
+}
+
+// AllPrivileges only supports tables, ALL is deprecated and does not apply to
+// schemas.
+func (sqlServerDialect) AllPrivileges(objectType string) []string {
+	if objectType == grantObjectTable {
+		return []string{"DELETE", "INSERT", "REFERENCES", "SELECT", "UPDATE"}
+	}
+	return nil
+}
+
+var _ databaseDialect = sqlServerDialect{}
+
+func (sqlServerDialect) ReadDatabase(ctx context.Context, db dbQueryer, name string) (*database, error) {
+	rows, err := db.QueryContext(ctx, "SELECT SUSER_SNAME(owner_sid), collation_name FROM sys.databases WHERE name = @p1", name)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return nil, rows.Err()
+	}
+
+	d := &database{Name: name}
+	err = rows.Scan(&d.Owner, &d.Collation)
+	if err != nil {
+		return nil, err
+	}
+	return d, rows.Err()
+}
+
+func (d sqlServerDialect) databaseOwner(db database) string {
+	return fmt.Sprintf("ALTER AUTHORIZATION ON DATABASE::%s TO %s", d.QuoteIdentifier(db.Name), d.QuoteIdentifier(*db.Owner))
+}
+
+// CreateDatabase sets the owner separately, as it is the creating login
+// otherwise.
+func (d sqlServerDialect) CreateDatabase(db database) ([]string, error) {
+	if db.Encoding != nil {
+		return nil, fmt.Errorf("database encodings are not supported by SQL Server, set the collation instead")
+	}
+
+	create := "CREATE DATABASE " + d.QuoteIdentifier(db.Name)
+	if db.Collation != nil {
+		create += " COLLATE " + *db.Collation
+	}
+
+	stmts := []string{create}
+	if db.Owner != nil {
+		stmts = append(stmts, d.databaseOwner(db))
+	}
+	return stmts, nil
+}
+
+func (d sqlServerDialect) AlterDatabase(prior, planned database) ([]string, error) {
+	if planned.Encoding != nil {
+		return nil, fmt.Errorf("database encodings are not supported by SQL Server, set the collation instead")
+	}
+
+	var stmts []string
+	if planned.Collation != nil && !equalFoldStringPtr(prior.Collation, planned.Collation) {
+		stmts = append(stmts, fmt.Sprintf("ALTER DATABASE %s COLLATE %s", d.QuoteIdentifier(planned.Name), *planned.Collation))
+	}
+	if planned.Owner != nil && !equalStringPtr(prior.Owner, planned.Owner) {
+		stmts = append(stmts, d.databaseOwner(planned))
+	}
+	return stmts, nil
+}
+
+func (d sqlServerDialect) DropDatabase(name string) string {
+	return "DROP DATABASE " + d.QuoteIdentifier(name)
+}
+
+func (sqlServerDialect) DatabaseRequiresReplace(prior, planned database) []string {
+	return nil
+}
+
+var _ schemaDialect = sqlServerDialect{}
+
+func (sqlServerDialect) ReadSchema(ctx context.Context, db dbQueryer, name string) (*dbSchema, error) {
+	owners, err := queryStrings(ctx, db, "SELECT USER_NAME(principal_id) FROM sys.schemas WHERE name = @p1", name)
+	if err != nil || len(owners) == 0 {
+		return nil, err
+	}
+	return &dbSchema{Name: name, Owner: &owners[0]}, nil
+}
+
+func (d sqlServerDialect) CreateSchema(s dbSchema) ([]string, error) {
+	create := "CREATE SCHEMA " + d.QuoteIdentifier(s.Name)
+	if s.Owner != nil {
+		create += " AUTHORIZATION " + d.QuoteIdentifier(*s.Owner)
+	}
+	return []string{create}, nil
+}
+
+func (d sqlServerDialect) AlterSchema(prior, planned dbSchema) ([]string, error) {
+	var stmts []string
+	if planned.Owner != nil && !equalStringPtr(prior.Owner, planned.Owner) {
+		stmts = append(stmts, fmt.Sprintf("ALTER AUTHORIZATION ON SCHEMA::%s TO %s", d.QuoteIdentifier(planned.Name), d.QuoteIdentifier(*planned.Owner)))
+	}
+	return stmts, nil
+}
+
+func (d sqlServerDialect) DropSchema(name string) string {
+	return "DROP SCHEMA " + d.QuoteIdentifier(name)
+}
+
+var _ rowsDialect = sqlServerDialect{}
+
+func (d sqlServerDialect) UpsertStatement(table string, columns, keyColumns []string) string {
+	quoted, placeholders, others := upsertParts(d, columns, keyColumns)
+
+	on := make([]string, len(keyColumns))
+	for i, c := range keyColumns {
+		on[i] = fmt.Sprintf("t.%s = s.%s", d.QuoteIdentifier(c), d.QuoteIdentifier(c))
+	}
+
+	var matched string
+	if len(others) > 0 {
+		sets := make([]string, len(others))
+		for i, c := range others {
+			sets[i] = fmt.Sprintf("%s = s.%s", d.QuoteIdentifier(c), d.QuoteIdentifier(c))
+		}
+		matched = " WHEN MATCHED THEN UPDATE SET " + strings.Join(sets, ", ")
+	}
+
+	sourceColumns := make([]string, len(columns))
+	for i, c := range columns {
+		sourceColumns[i] = "s." + d.QuoteIdentifier(c)
+	}
+
+	return fmt.Sprintf("MERGE INTO %s AS t USING (VALUES (%s)) AS s (%s) ON %s%s WHEN NOT MATCHED THEN INSERT (%s) VALUES (%s);",
+		table, placeholders, quoted, strings.Join(on, " AND "), matched, quoted, strings.Join(sourceColumns, ", "))
+}
+
+var _ viewDialect = sqlServerDialect{}
+
+// ReadView returns the definition as written, including the CREATE statement.
+func (sqlServerDialect) ReadView(ctx context.Context, db dbQueryer, schema, name string) (*string, error) {
+	return readDefinition(ctx, db, `SELECT m.definition
+FROM sys.sql_modules m
+JOIN sys.objects o ON o.object_id = m.object_id
+WHERE o.type = 'V' AND o.name = @p1
+AND SCHEMA_NAME(o.schema_id) = COALESCE(NULLIF(@p2, ''), SCHEMA_NAME())`, name, schema)
+}
+
+func (d sqlServerDialect) CreateView(v view) []string {
+	return []string{fmt.Sprintf("CREATE OR ALTER VIEW %s AS %s", qualifiedName(d, v.Schema, v.Name), v.Definition)}
+}
+
+func (d sqlServerDialect) DropView(schema, name string) string {
+	return "DROP VIEW " + qualifiedName(d, schema, name)
+}
+
+var _ functionDialect = sqlServerDialect{}
+
+// ReadFunction looks the function up by name, as SQL Server can't overload
+// functions.
+func (sqlServerDialect) ReadFunction(ctx context.Context, db dbQueryer, f function) (*string, error) {
+	return readDefinition(ctx, db, `SELECT m.definition
+FROM sys.sql_modules m
+JOIN sys.objects o ON o.object_id = m.object_id
+WHERE o.type IN ('FN', 'IF', 'TF') AND o.name = @p1
+AND SCHEMA_NAME(o.schema_id) = COALESCE(NULLIF(@p2, ''), SCHEMA_NAME())`, f.Name, f.Schema)
+}
+
+func (d sqlServerDialect) CreateFunction(f function) ([]string, error) {
+	if f.Language != nil {
+		return nil, fmt.Errorf("function languages are not supported by SQL Server")
+	}
+	return []string{fmt.Sprintf("CREATE OR ALTER FUNCTION %s(%s) RETURNS %s AS %s",
+		qualifiedName(d, f.Schema, f.Name), f.Arguments, f.Returns, f.Body)}, nil
+}
+
+func (d sqlServerDialect) DropFunction(f function) string {
+	return "DROP FUNCTION " + qualifiedName(d, f.Schema, f.Name)
+}
+
+var _ serverInfoDialect = sqlServerDialect{}
+
+func (d sqlServerDialect) ReadServerInfo(ctx context.Context, db dbQueryer) (*serverInfo, error) {
+	var (
+		info           serverInfo
+		productVersion string
+		edition        int64
+	)
+	err := scanServerInfo(ctx, db, d.VersionQuery(), &info.Version)
+	if err != nil {
+		return nil, err
+	}
+	err = scanServerInfo(ctx, db, `SELECT CAST(SERVERPROPERTY('ProductVersion') AS nvarchar(128)),
+	CAST(SERVERPROPERTY('EngineEdition') AS int),
+	DB_NAME(), SUSER_SNAME()`, &productVersion, &edition, &info.Database, &info.User)
+	if err != nil {
+		return nil, err
+	}
+	describeSQLServer(&info, productVersion, edition)
+	return &info, nil
+}
+
+// describeSQLServer parses the product version, as @@VERSION leads with the
+// release year, and tells Azure SQL Database and Managed Instance apart by
+// their engine edition. T-SQL has no IF NOT EXISTS clause.
+func describeSQLServer(info *serverInfo, productVersion string, edition int64) {
+	info.Major, info.Minor = parseVersion(productVersion)
+	info.TransactionalDDL = true
+	info.AdvisoryLocks = true
+
+	info.Product = productSQLServer
+	if edition == 5 || edition == 8 {
+		info.Product = productAzureSQL
+	}
+}
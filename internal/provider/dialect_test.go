@@ -0,0 +1,137 @@
+package provider
+
+import (
+	"crypto/tls"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDialects(t *testing.T) {
+	schemes := map[string]bool{}
+	for _, d := range dialects {
+		if dialectFor(d.Driver()) != d {
+			t.Errorf("driver %q is registered by more than one dialect", d.Driver())
+		}
+
+		for _, s := range d.Schemes() {
+			if schemes[s] {
+				t.Errorf("scheme %q is registered by more than one dialect", s)
+			}
+			schemes[s] = true
+
+			if dialectForScheme(s) != d {
+				t.Errorf("scheme %q does not resolve to the %q dialect", s, d.Driver())
+			}
+		}
+	}
+
+	if d := dialectFor("foo"); d != nil {
+		t.Errorf("expected no dialect for an unknown driver, got %q", d.Driver())
+	}
+	if d := dialectForScheme("foo"); d != nil {
+		t.Errorf("expected no dialect for an unknown scheme, got %q", d.Driver())
+	}
+}
+
+func TestDialect_QuoteIdentifier(t *testing.T) {
+	for name, c := range map[string]struct {
+		dialect  Dialect
+		expected string
+	}{
+		"pgx":       {postgresDialect{}, `"a""b` + "`c]d" + `"`},
+		"mysql":     {mysqlDialect{}, "`a\"b``c]d`"},
+		"sqlserver": {sqlServerDialect{}, "[a\"b`c]]d]"},
+		"azuresql":  {azureSQLDialect{}, "[a\"b`c]]d]"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			actual := c.dialect.QuoteIdentifier("a\"b`c]d")
+			if actual != c.expected {
+				t.Fatalf("expected %s, got %s", c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestDialect_Placeholder(t *testing.T) {
+	for name, c := range map[string]struct {
+		dialect  Dialect
+		expected []string
+	}{
+		"pgx":       {postgresDialect{}, []string{"$1", "$2"}},
+		"mysql":     {mysqlDialect{}, []string{"?", "?"}},
+		"sqlserver": {sqlServerDialect{}, []string{"@p1", "@p2"}},
+		"azuresql":  {azureSQLDialect{}, []string{"@p1", "@p2"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			actual := []string{c.dialect.Placeholder(1), c.dialect.Placeholder(2)}
+			if diff := cmp.Diff(c.expected, actual); diff != "" {
+				t.Fatalf("unexpected placeholders (-expected +actual):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDialect_SplitStatements(t *testing.T) {
+	for name, c := range map[string]struct {
+		dialect  Dialect
+		script   string
+		expected []string
+	}{
+		"pgx": {
+			postgresDialect{},
+			"CREATE TABLE a (id int);\nGO\nCREATE TABLE b (id int);",
+			[]string{"CREATE TABLE a (id int);\nGO\nCREATE TABLE b (id int);"},
+		},
+		"sqlserver": {
+			sqlServerDialect{},
+			"CREATE TABLE a (id int);\n go \nCREATE VIEW v AS SELECT 1 AS going;\r\nGo\r\n\nGO\n",
+			[]string{"CREATE TABLE a (id int);", "CREATE VIEW v AS SELECT 1 AS going;"},
+		},
+		"sqlserver literal": {
+			sqlServerDialect{},
+			"INSERT INTO t VALUES ('it''s\nGO\n');\nGO\n/* a comment\nGO\n*/ SELECT 1 -- it's\nGO\nSELECT 2",
+			[]string{"INSERT INTO t VALUES ('it''s\nGO\n');", "/* a comment\nGO\n*/ SELECT 1 -- it's", "SELECT 2"},
+		},
+		"sqlserver no separator": {
+			sqlServerDialect{},
+			"SELECT 1",
+			[]string{"SELECT 1"},
+		},
+		"azuresql": {
+			azureSQLDialect{},
+			"SELECT 1\nGO\nSELECT 2",
+			[]string{"SELECT 1", "SELECT 2"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			actual := c.dialect.SplitStatements(c.script)
+			if diff := cmp.Diff(c.expected, actual); diff != "" {
+				t.Fatalf("unexpected batches (-expected +actual):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMySQLConnector_registrations(t *testing.T) {
+	registered := len(mysqlDialers)
+	one, two := &sshTunnel{addr: "one:22"}, &sshTunnel{addr: "two:22"}
+	if registerMySQLDialer(one) != registerMySQLDialer(one) {
+		t.Errorf("expected a dialer to be registered once")
+	}
+	if registerMySQLDialer(one) == registerMySQLDialer(two) {
+		t.Errorf("expected dialers to be registered with different networks")
+	}
+
+	// TLS configs are only registered while creating the connector
+	opts := connectorOptions{TLS: &tls.Config{ServerName: "localhost"}, Dialer: one}
+	for i := 0; i < 2; i++ {
+		_, err := mysqlDialect{}.Connector("user@tcp(localhost:3306)/db", opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(mysqlDialers) != registered+2 {
+		t.Errorf("expected 2 more registered dialers, got %d", len(mysqlDialers)-registered)
+	}
+}
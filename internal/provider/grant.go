@@ -0,0 +1,255 @@
+package provider
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	grantObjectTable  = "table"
+	grantObjectSchema = "schema"
+)
+
+var grantObjectTypes = []string{grantObjectTable, grantObjectSchema}
+
+// grant is a set of privileges of a role on objects of the same type. For
+// schema grants the objects are the schemas themselves, and Schema is empty.
+type grant struct {
+	Role            string
+	ObjectType      string
+	Schema          string
+	Objects         []string
+	Privileges      []string
+	WithGrantOption bool
+}
+
+// objectPrivileges maps the upper cased privileges held on an object to
+// whether they are grantable.
+type objectPrivileges map[string]bool
+
+// grantDialect is implemented by the dialects that support managing grants.
+type grantDialect interface {
+	Dialect
+
+	// ReadGrants introspects the privileges of the role on all objects of the
+	// type, in the schema for tables. An empty schema is the current schema.
+	ReadGrants(ctx context.Context, db dbQueryer, role, objectType, schema string) (map[string]objectPrivileges, error)
+
+	// GrantTarget returns the object as used in the ON clause of GRANT and
+	// REVOKE.
+	GrantTarget(objectType, schema, object string) string
+
+	// Grantee returns the role as used in the TO and FROM clauses.
+	Grantee(role string) string
+
+	// RevokeStatement revokes the privileges, any of which may be grantable.
+	RevokeStatement(privileges []string, target, grantee string, grantable bool) string
+
+	// RevokeGrantOptionStatement revokes the grant option only, keeping the
+	// privileges.
+	RevokeGrantOptionStatement(privileges []string, target, grantee string) string
+
+	// AllPrivileges returns the privileges granted by ALL on the object type,
+	// which the catalogs report individually, or nil if ALL is not supported.
+	AllPrivileges(objectType string) []string
+}
+
+// isAllPrivileges reports whether the privilege is ALL or ALL PRIVILEGES.
+func isAllPrivileges(privilege string) bool {
+	switch strings.ToUpper(privilege) {
+	case "ALL", "ALL PRIVILEGES":
+		return true
+	}
+	return false
+}
+
+// expandPrivileges returns the upper cased privileges, with ALL replaced by
+// the individual privileges it grants, so that they can be compared with the
+// catalog.
+func expandPrivileges(d grantDialect, objectType string, privileges []string) map[string]bool {
+	set := map[string]bool{}
+	for _, p := range privileges {
+		all := d.AllPrivileges(objectType)
+		if !isAllPrivileges(p) || all == nil {
+			set[strings.ToUpper(p)] = true
+			continue
+		}
+		for _, a := range all {
+			set[a] = true
+		}
+	}
+	return set
+}
+
+// scanGrants reads rows of object name, privilege and whether it is
+// grantable.
+func scanGrants(rows *sql.Rows) (map[string]objectPrivileges, error) {
+	defer rows.Close()
+
+	grants := map[string]objectPrivileges{}
+	for rows.Next() {
+		var object, privilege string
+		var grantable bool
+		err := rows.Scan(&object, &privilege, &grantable)
+		if err != nil {
+			return nil, err
+		}
+
+		if grants[object] == nil {
+			grants[object] = objectPrivileges{}
+		}
+		privilege = strings.ToUpper(privilege)
+		grants[object][privilege] = grants[object][privilege] || grantable
+	}
+
+	return grants, rows.Err()
+}
+
+// grantStatements returns the GRANT and REVOKE statements to go from the
+// actual privileges to the planned grant. Privileges on objects no longer
+// planned are only revoked if they were part of the prior grant, other
+// privileges held on planned objects are revoked.
+func grantStatements(d grantDialect, prior, planned grant, actual map[string]objectPrivileges) []string {
+	inPlanned := map[string]bool{}
+	for _, o := range planned.Objects {
+		inPlanned[o] = true
+	}
+	objects := append([]string{}, planned.Objects...)
+	for _, o := range prior.Objects {
+		if !inPlanned[o] {
+			objects = append(objects, o)
+		}
+	}
+	sort.Strings(objects)
+
+	priorPrivileges := expandPrivileges(d, prior.ObjectType, prior.Privileges)
+	plannedPrivileges := expandPrivileges(d, planned.ObjectType, planned.Privileges)
+	grantee := d.Grantee(planned.Role)
+
+	var stmts []string
+	for _, o := range objects {
+		target := d.GrantTarget(planned.ObjectType, planned.Schema, o)
+		have := actual[o]
+
+		var add, addGrantOption, revokeGrantOption, revoke []string
+		var revokeGrantable bool
+		if inPlanned[o] {
+			for _, p := range sortedPrivileges(plannedPrivileges) {
+				grantable, held := have[p]
+				switch {
+				case !held:
+					add = append(add, p)
+				case planned.WithGrantOption && !grantable:
+					addGrantOption = append(addGrantOption, p)
+				case !planned.WithGrantOption && grantable:
+					revokeGrantOption = append(revokeGrantOption, p)
+				}
+			}
+		}
+		for _, p := range sortedPrivileges(have) {
+			if inPlanned[o] && plannedPrivileges[p] {
+				continue
+			}
+			if !inPlanned[o] && !priorPrivileges[p] {
+				continue
+			}
+			revoke = append(revoke, p)
+			revokeGrantable = revokeGrantable || have[p]
+		}
+
+		if len(revoke) > 0 {
+			stmts = append(stmts, d.RevokeStatement(revoke, target, grantee, revokeGrantable))
+		}
+		if len(revokeGrantOption) > 0 {
+			stmts = append(stmts, d.RevokeGrantOptionStatement(revokeGrantOption, target, grantee))
+		}
+		if planned.WithGrantOption {
+			add = append(add, addGrantOption...)
+			sort.Strings(add)
+		}
+		if len(add) > 0 {
+			stmt := fmt.Sprintf("GRANT %s ON %s TO %s", strings.Join(add, ", "), target, grantee)
+			if planned.WithGrantOption {
+				stmt += " WITH GRANT OPTION"
+			}
+			stmts = append(stmts, stmt)
+		}
+	}
+
+	return stmts
+}
+
+// readGrantState reconciles the actual privileges with the prior grant. A
+// privilege is held if it is held on all of the objects, and an unmanaged one
+// if it is held on any of them, so that either drift is planned away. ALL is
+// held if all of the privileges it grants are.
+func readGrantState(d grantDialect, prior grant, actual map[string]objectPrivileges) grant {
+	state := prior
+	state.Privileges = nil
+
+	seen := map[string]bool{}
+	for _, p := range prior.Privileges {
+		privileges := []string{strings.ToUpper(p)}
+		if all := d.AllPrivileges(prior.ObjectType); isAllPrivileges(p) && all != nil {
+			privileges = all
+		}
+		if seen[privileges[0]] && len(privileges) == 1 {
+			continue
+		}
+
+		held := true
+		for _, privilege := range privileges {
+			seen[privilege] = true
+			for _, o := range prior.Objects {
+				if _, ok := actual[o][privilege]; !ok {
+					held = false
+				}
+			}
+		}
+		if held {
+			// keep the configured spelling
+			state.Privileges = append(state.Privileges, p)
+		}
+	}
+
+	unmanaged := map[string]bool{}
+	for _, o := range prior.Objects {
+		for p := range actual[o] {
+			if !seen[p] {
+				unmanaged[p] = true
+			}
+		}
+	}
+	state.Privileges = append(state.Privileges, sortedPrivileges(unmanaged)...)
+
+	// the grant option is held if all of the privileges are grantable, or
+	// if any is when it was not granted
+	grantable, anyGrantable := true, false
+	for _, o := range prior.Objects {
+		for p := range expandPrivileges(d, prior.ObjectType, prior.Privileges) {
+			if g, ok := actual[o][p]; ok {
+				grantable = grantable && g
+				anyGrantable = anyGrantable || g
+			}
+		}
+	}
+	if prior.WithGrantOption {
+		state.WithGrantOption = grantable && anyGrantable
+	} else {
+		state.WithGrantOption = anyGrantable
+	}
+
+	return state
+}
+
+func sortedPrivileges(privileges map[string]bool) []string {
+	sorted := make([]string, 0, len(privileges))
+	for p := range privileges {
+		sorted = append(sorted, p)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
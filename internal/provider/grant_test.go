@@ -0,0 +1,186 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestGrantStatements(t *testing.T) {
+	prior := grant{
+		Role:       "app",
+		ObjectType: grantObjectTable,
+		Schema:     "public",
+		Objects:    []string{"items", "orders"},
+		Privileges: []string{"SELECT", "INSERT"},
+	}
+	planned := grant{
+		Role:            "app",
+		ObjectType:      grantObjectTable,
+		Schema:          "public",
+		Objects:         []string{"items", "users"},
+		Privileges:      []string{"select", "update"},
+		WithGrantOption: true,
+	}
+	actual := map[string]objectPrivileges{
+		"items":  {"SELECT": false, "INSERT": false, "DELETE": true},
+		"orders": {"SELECT": false, "INSERT": false, "TRUNCATE": false},
+		"users":  {"SELECT": true},
+	}
+
+	for name, c := range map[string]struct {
+		dialect  grantDialect
+		expected []string
+	}{
+		"pgx": {
+			postgresDialect{},
+			[]string{
+				`REVOKE DELETE, INSERT ON TABLE "public"."items" FROM "app"`,
+				`GRANT SELECT, UPDATE ON TABLE "public"."items" TO "app" WITH GRANT OPTION`,
+				`REVOKE INSERT, SELECT ON TABLE "public"."orders" FROM "app"`,
+				`GRANT UPDATE ON TABLE "public"."users" TO "app" WITH GRANT OPTION`,
+			},
+		},
+		"mysql": {
+			mysqlDialect{},
+			[]string{
+				"REVOKE DELETE, INSERT ON `public`.`items` FROM 'app'@'%'",
+				"GRANT SELECT, UPDATE ON `public`.`items` TO 'app'@'%' WITH GRANT OPTION",
+				"REVOKE INSERT, SELECT ON `public`.`orders` FROM 'app'@'%'",
+				"GRANT UPDATE ON `public`.`users` TO 'app'@'%' WITH GRANT OPTION",
+			},
+		},
+		"sqlserver": {
+			sqlServerDialect{},
+			[]string{
+				"REVOKE DELETE, INSERT ON OBJECT::[public].[items] FROM [app] CASCADE",
+				"GRANT SELECT, UPDATE ON OBJECT::[public].[items] TO [app] WITH GRANT OPTION",
+				"REVOKE INSERT, SELECT ON OBJECT::[public].[orders] FROM [app]",
+				"GRANT UPDATE ON OBJECT::[public].[users] TO [app] WITH GRANT OPTION",
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			actual := grantStatements(c.dialect, prior, planned, actual)
+			if diff := cmp.Diff(c.expected, actual); diff != "" {
+				t.Fatalf("unexpected statements (-expected +actual):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGrantStatements_revokeGrantOption(t *testing.T) {
+	g := grant{
+		Role:       "app",
+		ObjectType: grantObjectSchema,
+		Objects:    []string{"reporting"},
+		Privileges: []string{"USAGE"},
+	}
+	actual := map[string]objectPrivileges{
+		"reporting": {"USAGE": true},
+	}
+
+	for name, c := range map[string]struct {
+		dialect  grantDialect
+		expected []string
+	}{
+		"pgx":       {postgresDialect{}, []string{`REVOKE GRANT OPTION FOR USAGE ON SCHEMA "reporting" FROM "app"`}},
+		"mysql":     {mysqlDialect{}, []string{"REVOKE GRANT OPTION ON `reporting`.* FROM 'app'@'%'"}},
+		"sqlserver": {sqlServerDialect{}, []string{"REVOKE GRANT OPTION FOR USAGE ON SCHEMA::[reporting] FROM [app] CASCADE"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			actual := grantStatements(c.dialect, g, g, actual)
+			if diff := cmp.Diff(c.expected, actual); diff != "" {
+				t.Fatalf("unexpected statements (-expected +actual):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReadGrantState(t *testing.T) {
+	prior := grant{
+		Role:       "app",
+		ObjectType: grantObjectTable,
+		Objects:    []string{"items", "orders"},
+		Privileges: []string{"select", "INSERT"},
+	}
+
+	for name, c := range map[string]struct {
+		actual          map[string]objectPrivileges
+		privileges      []string
+		withGrantOption bool
+	}{
+		"unchanged": {
+			map[string]objectPrivileges{
+				"items":  {"SELECT": false, "INSERT": false},
+				"orders": {"SELECT": false, "INSERT": false},
+			},
+			[]string{"select", "INSERT"},
+			false,
+		},
+		"revoked from one object": {
+			map[string]objectPrivileges{
+				"items":  {"SELECT": false, "INSERT": false},
+				"orders": {"SELECT": false},
+			},
+			[]string{"select"},
+			false,
+		},
+		"granted outside": {
+			map[string]objectPrivileges{
+				"items":  {"SELECT": false, "INSERT": false, "DELETE": false},
+				"orders": {"SELECT": true, "INSERT": false},
+			},
+			[]string{"select", "INSERT", "DELETE"},
+			true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			state := readGrantState(postgresDialect{}, prior, c.actual)
+			if diff := cmp.Diff(c.privileges, state.Privileges); diff != "" {
+				t.Fatalf("unexpected privileges (-expected +actual):\n%s", diff)
+			}
+			if state.WithGrantOption != c.withGrantOption {
+				t.Fatalf("expected with_grant_option %t, got %t", c.withGrantOption, state.WithGrantOption)
+			}
+		})
+	}
+}
+
+func TestGrantStatements_all(t *testing.T) {
+	for name, d := range map[string]grantDialect{
+		"pgx":       postgresDialect{},
+		"mysql":     mysqlDialect{},
+		"sqlserver": sqlServerDialect{},
+	} {
+		for _, all := range []string{"ALL", "all privileges"} {
+			t.Run(name+" "+all, func(t *testing.T) {
+				g := grant{
+					Role:       "app",
+					ObjectType: grantObjectTable,
+					Objects:    []string{"items"},
+					Privileges: []string{all},
+				}
+
+				stmts := grantStatements(d, grant{}, g, nil)
+				if len(stmts) != 1 {
+					t.Fatalf("expected a single GRANT, got %v", stmts)
+				}
+
+				// the catalog reports the individual privileges granted by ALL
+				actual := map[string]objectPrivileges{"items": {}}
+				for _, p := range d.AllPrivileges(grantObjectTable) {
+					actual["items"][p] = false
+				}
+
+				state := readGrantState(d, g, actual)
+				if diff := cmp.Diff([]string{all}, state.Privileges); diff != "" {
+					t.Fatalf("unexpected privileges (-expected +actual):\n%s", diff)
+				}
+				if stmts := grantStatements(d, state, g, actual); len(stmts) != 0 {
+					t.Fatalf("expected no statements on the second plan, got %v", stmts)
+				}
+			})
+		}
+	}
+}
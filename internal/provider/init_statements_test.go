@@ -0,0 +1,113 @@
+package provider
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type fakeConnector struct {
+	conn driver.Conn
+}
+
+func (c *fakeConnector) Connect(context.Context) (driver.Conn, error) {
+	return c.conn, nil
+}
+
+func (c *fakeConnector) Driver() driver.Driver {
+	return nil
+}
+
+type fakeConn struct {
+	driver.Conn
+
+	execs  []string
+	err    error
+	closed bool
+}
+
+func (c *fakeConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.execs = append(c.execs, query)
+	return driver.RowsAffected(0), c.err
+}
+
+func (c *fakeConn) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestInitConnector_Connect(t *testing.T) {
+	statements := []string{"SET @a = 1", "SET @b = 2"}
+
+	conn := &fakeConn{}
+	c := &initConnector{Connector: &fakeConnector{conn}, statements: statements}
+
+	actual, err := c.Connect(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual != conn {
+		t.Fatalf("expected the wrapped connection")
+	}
+	if !reflect.DeepEqual(conn.execs, statements) {
+		t.Fatalf("expected %v to be run, got %v", statements, conn.execs)
+	}
+
+	conn = &fakeConn{err: errors.New("syntax error")}
+	c = &initConnector{Connector: &fakeConnector{conn}, statements: statements}
+
+	_, err = c.Connect(context.Background())
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if !conn.closed {
+		t.Fatal("expected the connection to be closed")
+	}
+	if len(conn.execs) != 1 {
+		t.Fatalf("expected statements to stop after the error, got %v", conn.execs)
+	}
+}
+
+// fakePrepareConn can only run statements by preparing them, like go-mssqldb.
+type fakePrepareConn struct {
+	driver.Conn
+
+	execs []string
+}
+
+func (c *fakePrepareConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	return &fakeStmt{conn: c, query: query}, nil
+}
+
+type fakeStmt struct {
+	driver.Stmt
+
+	conn  *fakePrepareConn
+	query string
+}
+
+func (s *fakeStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	s.conn.execs = append(s.conn.execs, s.query)
+	return driver.RowsAffected(0), nil
+}
+
+func (s *fakeStmt) Close() error {
+	return nil
+}
+
+func TestInitConnector_Connect_prepare(t *testing.T) {
+	statements := []string{"SET LANGUAGE Deutsch"}
+
+	conn := &fakePrepareConn{}
+	c := &initConnector{Connector: &fakeConnector{conn}, statements: statements}
+
+	_, err := c.Connect(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(conn.execs, statements) {
+		t.Fatalf("expected %v to be run, got %v", statements, conn.execs)
+	}
+}
@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+)
+
+// pgExtension is a PostgreSQL extension as configured or introspected. The
+// schema and version are nil when not configured, to use the defaults of the
+// extension.
+type pgExtension struct {
+	Name    string
+	Schema  *string
+	Version *string
+}
+
+// readPgExtension introspects the extension, returning nil if it is not
+// installed.
+func readPgExtension(ctx context.Context, db dbQueryer, name string) (*pgExtension, error) {
+	rows, err := db.QueryContext(ctx, `SELECT n.nspname, e.extversion
+FROM pg_catalog.pg_extension e
+JOIN pg_catalog.pg_namespace n ON n.oid = e.extnamespace
+WHERE e.extname = $1`, name)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return nil, rows.Err()
+	}
+	e := pgExtension{Name: name}
+	err = rows.Scan(&e.Schema, &e.Version)
+	if err != nil {
+		return nil, err
+	}
+	return &e, rows.Err()
+}
+
+func createPgExtension(e pgExtension) []string {
+	d := postgresDialect{}
+	create := "CREATE EXTENSION " + d.QuoteIdentifier(e.Name)
+	if e.Schema != nil {
+		create += " SCHEMA " + d.QuoteIdentifier(*e.Schema)
+	}
+	if e.Version != nil {
+		create += " VERSION " + d.QuoteString(*e.Version)
+	}
+	return []string{create}
+}
+
+// alterPgExtension moves the extension to the planned schema and updates it to
+// the planned version, using the update scripts shipped with the extension.
+func alterPgExtension(prior, planned pgExtension) []string {
+	d := postgresDialect{}
+	name := d.QuoteIdentifier(planned.Name)
+
+	var stmts []string
+	if planned.Schema != nil && !equalStringPtr(prior.Schema, planned.Schema) {
+		stmts = append(stmts, fmt.Sprintf("ALTER EXTENSION %s SET SCHEMA %s", name, d.QuoteIdentifier(*planned.Schema)))
+	}
+	if planned.Version != nil && !equalStringPtr(prior.Version, planned.Version) {
+		stmts = append(stmts, fmt.Sprintf("ALTER EXTENSION %s UPDATE TO %s", name, d.QuoteString(*planned.Version)))
+	}
+	return stmts
+}
+
+func dropPgExtension(name string) string {
+	return "DROP EXTENSION " + postgresDialect{}.QuoteIdentifier(name)
+}
@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPgExtensionStatements(t *testing.T) {
+	public, extensions := "public", "extensions"
+	oldVersion, newVersion := "1.2", "1.3"
+
+	if diff := cmp.Diff([]string{`CREATE EXTENSION "pgcrypto"`}, createPgExtension(pgExtension{Name: "pgcrypto"})); diff != "" {
+		t.Fatalf("unexpected statements (-expected +actual):\n%s", diff)
+	}
+
+	actual := createPgExtension(pgExtension{Name: "pgcrypto", Schema: &extensions, Version: &oldVersion})
+	if diff := cmp.Diff([]string{`CREATE EXTENSION "pgcrypto" SCHEMA "extensions" VERSION '1.2'`}, actual); diff != "" {
+		t.Fatalf("unexpected statements (-expected +actual):\n%s", diff)
+	}
+
+	actual = alterPgExtension(
+		pgExtension{Name: "pgcrypto", Schema: &public, Version: &oldVersion},
+		pgExtension{Name: "pgcrypto", Schema: &extensions, Version: &newVersion},
+	)
+	expected := []string{
+		`ALTER EXTENSION "pgcrypto" SET SCHEMA "extensions"`,
+		`ALTER EXTENSION "pgcrypto" UPDATE TO '1.3'`,
+	}
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Fatalf("unexpected statements (-expected +actual):\n%s", diff)
+	}
+
+	// unset attributes keep what is installed
+	actual = alterPgExtension(
+		pgExtension{Name: "pgcrypto", Schema: &public, Version: &oldVersion},
+		pgExtension{Name: "pgcrypto"},
+	)
+	if len(actual) != 0 {
+		t.Fatalf("expected no statements, got %v", actual)
+	}
+}
+
+func TestPgExtensionDriverDiags(t *testing.T) {
+	if diags := pgExtensionDriverDiags(dataSource{driver: driverPgx}); diags != nil {
+		t.Fatalf("unexpected diagnostics for pgx: %v", diags)
+	}
+	for _, driver := range []driverName{driverMySQL, driverSQLServer, driverAzureSQL} {
+		if diags := pgExtensionDriverDiags(dataSource{driver: driver}); len(diags) != 1 {
+			t.Fatalf("expected a diagnostic for %s, got %v", driver, diags)
+		}
+	}
+}
@@ -1,7 +1,15 @@
 package provider
 
 import (
+	"context"
+	"fmt"
+	neturl "net/url"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	helperresource "github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	// "github.com/ialexj/terraform-provider-sql/internal/server"
 )
 
@@ -10,3 +18,189 @@ func TestServer(t *testing.T) {
 
 	// s.Test(t)
 }
+
+// testProviderConfig returns a provider config with all attributes null
+// except those given.
+func testProviderConfig(p *provider, attrs map[string]tftypes.Value) map[string]tftypes.Value {
+	ty := p.Schema(context.Background()).ValueType().(tftypes.Object)
+
+	config := map[string]tftypes.Value{}
+	for name, attrTy := range ty.AttributeTypes {
+		config[name] = tftypes.NewValue(attrTy, nil)
+	}
+	for name, v := range attrs {
+		config[name] = v
+	}
+
+	return config
+}
+
+func TestProvider_durations(t *testing.T) {
+	p := &provider{}
+
+	config := testProviderConfig(p, map[string]tftypes.Value{
+		"url":                tftypes.NewValue(tftypes.String, "postgres://localhost/db"),
+		"conn_max_lifetime":  tftypes.NewValue(tftypes.String, "5m"),
+		"conn_max_idle_time": tftypes.NewValue(tftypes.String, "30s"),
+	})
+
+	diags, err := p.Validate(context.Background(), config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(diags) > 0 {
+		t.Fatalf("unexpected diags: %v", diags[0].Summary)
+	}
+
+	_, err = p.Configure(context.Background(), config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.ConnMaxLifetime != 5*time.Minute || p.ConnMaxIdleTime != 30*time.Second {
+		t.Fatalf("unexpected durations %s and %s", p.ConnMaxLifetime, p.ConnMaxIdleTime)
+	}
+
+	for _, value := range []string{"5", "soon", "-1m"} {
+		config := testProviderConfig(p, map[string]tftypes.Value{
+			"conn_max_idle_time": tftypes.NewValue(tftypes.String, value),
+		})
+
+		diags, err := p.Validate(context.Background(), config)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(diags) != 1 {
+			t.Fatalf("expected a diag for %q, got %d", value, len(diags))
+		}
+
+		expected := tftypes.NewAttributePath().WithAttributeName("conn_max_idle_time")
+		if !diags[0].Attribute.Equal(expected) {
+			t.Fatalf("expected diag on %s, got %s", expected, diags[0].Attribute)
+		}
+	}
+}
+
+func TestProvider_connection(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping long test")
+	}
+
+	for _, server := range testServers {
+		t.Run(server.ServerType, func(t *testing.T) {
+			url, scheme, err := server.URL()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if scheme != "postgres" && scheme != "sqlserver" {
+				t.Skipf("url for %s is not parsed by this test", server.ServerType)
+			}
+
+			u, err := neturl.Parse(url)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			password, _ := u.User.Password()
+			params := []string{}
+			for k := range u.Query() {
+				params = append(params, fmt.Sprintf("%q = %q", k, u.Query().Get(k)))
+			}
+
+			helperresource.UnitTest(t, helperresource.TestCase{
+				ProtoV6ProviderFactories: protoV6ProviderFactories,
+				Steps: []helperresource.TestStep{
+					{
+						Config: fmt.Sprintf(`
+provider "sql" {
+	max_idle_conns = 0
+
+	connection {
+		driver   = %q
+		host     = %q
+		port     = %s
+		database = %q
+		username = %q
+		password = %q
+		params   = {
+			%s
+		}
+	}
+}
+
+data "sql_query" "test" {
+	query = "select 1 as one"
+}
+
+output "one" {
+	value = data.sql_query.test.scalar
+}
+				`, scheme, u.Hostname(), u.Port(), strings.TrimPrefix(u.Path, "/"), u.User.Username(), password, strings.Join(params, "\n")),
+						Check: helperresource.TestCheckOutput("one", "1"),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestProvider_Configure_diags(t *testing.T) {
+	p := &provider{}
+	ty := p.Schema(context.Background()).ValueType().(tftypes.Object)
+	retryTy := ty.AttributeTypes["connect_retry"].(tftypes.Object)
+
+	retry := map[string]tftypes.Value{}
+	for name, attrTy := range retryTy.AttributeTypes {
+		retry[name] = tftypes.NewValue(attrTy, nil)
+	}
+	retry["initial_interval"] = tftypes.NewValue(tftypes.String, "soon")
+
+	for name, c := range map[string]struct {
+		attrs    map[string]tftypes.Value
+		expected *tftypes.AttributePath
+	}{
+		"url": {
+			map[string]tftypes.Value{
+				"url": tftypes.NewValue(tftypes.String, "oracle://localhost/db"),
+			},
+			tftypes.NewAttributePath().WithAttributeName("url"),
+		},
+		"pool size": {
+			map[string]tftypes.Value{
+				"max_open_conns": tftypes.NewValue(tftypes.Number, 1.5),
+			},
+			tftypes.NewAttributePath().WithAttributeName("max_open_conns"),
+		},
+		"duration": {
+			map[string]tftypes.Value{
+				"conn_max_lifetime": tftypes.NewValue(tftypes.String, "soon"),
+			},
+			tftypes.NewAttributePath().WithAttributeName("conn_max_lifetime"),
+		},
+		"password_file": {
+			map[string]tftypes.Value{
+				"password_file": tftypes.NewValue(tftypes.String, ""),
+			},
+			tftypes.NewAttributePath().WithAttributeName("password_file"),
+		},
+		"connect_retry": {
+			map[string]tftypes.Value{
+				"connect_retry": tftypes.NewValue(retryTy, retry),
+			},
+			tftypes.NewAttributePath().WithAttributeName("connect_retry").WithAttributeName("initial_interval"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			diags, err := p.Configure(context.Background(), testProviderConfig(p, c.attrs))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(diags) != 1 {
+				t.Fatalf("expected a single diag, got %d", len(diags))
+			}
+			if !diags[0].Attribute.Equal(c.expected) {
+				t.Fatalf("expected diag on %s, got %s: %s", c.expected, diags[0].Attribute, diags[0].Summary)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// renderQuery renders the query as a Go template using vars. Variables that are
+// output directly are bound as query parameters, while variables passed to the
+// ident function are quoted as identifiers for the driver, so neither can be
+// used to inject SQL.
+func renderQuery(driver driverName, query string, vars map[string]string) (string, []interface{}, error) {
+	r := &queryRenderer{
+		driver: driver,
+	}
+
+	data := map[string]queryVar{}
+	for k, v := range vars {
+		data[k] = queryVar{
+			r:     r,
+			value: v,
+		}
+	}
+
+	t, err := template.New("query").
+		Option("missingkey=error").
+		Funcs(template.FuncMap{
+			"ident": r.ident,
+		}).
+		Parse(query)
+	if err != nil {
+		return "", nil, fmt.Errorf("unable to parse query template: %w", err)
+	}
+
+	var sb strings.Builder
+	err = t.Execute(&sb, data)
+	if err != nil {
+		return "", nil, fmt.Errorf("unable to render query template: %w", err)
+	}
+
+	return sb.String(), r.args, nil
+}
+
+type queryRenderer struct {
+	driver driverName
+	args   []interface{}
+}
+
+// bind adds the value as a query parameter and returns its placeholder.
+func (r *queryRenderer) bind(value string) string {
+	r.args = append(r.args, value)
+	return r.sqlDialect().Placeholder(len(r.args))
+}
+
+func (r *queryRenderer) ident(v interface{}) (string, error) {
+	var name string
+	switch v := v.(type) {
+	case queryVar:
+		name = v.value
+	case string:
+		name = v
+	default:
+		return "", fmt.Errorf("unexpected identifier type %T", v)
+	}
+
+	if name == "" {
+		return "", fmt.Errorf("identifier can't be empty")
+	}
+
+	// qualified names are quoted part by part, ie. schema.table
+	parts := strings.Split(name, ".")
+	for i, part := range parts {
+		parts[i] = r.sqlDialect().QuoteIdentifier(part)
+	}
+
+	return strings.Join(parts, "."), nil
+}
+
+// queryVar is a template variable, when rendered directly it is bound as a
+// parameter rather than being written in to the query.
+type queryVar struct {
+	r     *queryRenderer
+	value string
+}
+
+func (v queryVar) String() string {
+	return v.r.bind(v.value)
+}
+
+// sqlDialect returns the dialect of the driver, defaulting to standard SQL
+// quoting and numbered placeholders as used by postgres.
+func (r *queryRenderer) sqlDialect() Dialect {
+	if d := dialectFor(r.driver); d != nil {
+		return d
+	}
+	return postgresDialect{}
+}
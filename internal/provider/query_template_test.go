@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRenderQuery(t *testing.T) {
+	vars := map[string]string{
+		"table": `weird"table`,
+		"name":  "Robert'); DROP TABLE students;--",
+		"limit": "10",
+	}
+
+	for name, c := range map[string]struct {
+		driver        driverName
+		query         string
+		expectedQuery string
+		expectedArgs  []interface{}
+	}{
+		"pgx": {
+			"pgx",
+			"SELECT * FROM {{ ident .table }} WHERE name = {{ .name }} LIMIT {{ .limit }}",
+			`SELECT * FROM "weird""table" WHERE name = $1 LIMIT $2`,
+			[]interface{}{"Robert'); DROP TABLE students;--", "10"},
+		},
+		"mysql": {
+			"mysql",
+			"SELECT * FROM {{ ident .table }} WHERE name = {{ .name }}",
+			"SELECT * FROM `weird\"table` WHERE name = ?",
+			[]interface{}{"Robert'); DROP TABLE students;--"},
+		},
+		"sqlserver": {
+			"sqlserver",
+			"SELECT TOP ({{ .limit }}) * FROM {{ ident .table }} WHERE name = {{ .name }}",
+			`SELECT TOP (@p1) * FROM [weird"table] WHERE name = @p2`,
+			[]interface{}{"10", "Robert'); DROP TABLE students;--"},
+		},
+		"qualified": {
+			"pgx",
+			`SELECT * FROM {{ ident "public.users" }}`,
+			`SELECT * FROM "public"."users"`,
+			nil,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			query, args, err := renderQuery(c.driver, c.query, vars)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if query != c.expectedQuery {
+				t.Fatalf("expected query %q, got %q", c.expectedQuery, query)
+			}
+
+			if !cmp.Equal(c.expectedArgs, args) {
+				t.Fatalf("args do not match:\n%s", cmp.Diff(c.expectedArgs, args))
+			}
+		})
+	}
+}
+
+func TestRenderQuery_missingVar(t *testing.T) {
+	_, _, err := renderQuery("pgx", "SELECT {{ .missing }}", map[string]string{})
+	if err == nil {
+		t.Fatalf("expected error but got none")
+	}
+}
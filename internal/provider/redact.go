@@ -0,0 +1,137 @@
+package provider
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+const redacted = "xxxxx"
+
+// secrets returns the credentials contained in the url of the data source, in
+// both their raw and decoded forms, longest first.
+func (ds dataSource) secrets() []string {
+	var secrets []string
+
+	switch ds.driver {
+	case driverMySQL:
+		cfg, err := mysql.ParseDSN(ds.url)
+		if err == nil && cfg.Passwd != "" {
+			secrets = append(secrets, cfg.Passwd)
+		}
+	default:
+		u, err := url.Parse(ds.url)
+		if err != nil {
+			break
+		}
+
+		if password, ok := u.User.Password(); ok && password != "" {
+			secrets = append(secrets, password, url.QueryEscape(password), url.PathEscape(password))
+
+			// the url may escape the password differently than the url package
+			// would, so also look for it as it was written
+			authority := strings.TrimPrefix(ds.url, u.Scheme+"://")
+			if i := strings.IndexAny(authority, "/?#"); i >= 0 {
+				authority = authority[:i]
+			}
+			if i := strings.LastIndex(authority, "@"); i >= 0 {
+				if _, raw, ok := strings.Cut(authority[:i], ":"); ok {
+					secrets = append(secrets, raw)
+				}
+			}
+		}
+
+		for k, vs := range u.Query() {
+			switch strings.ToLower(k) {
+			case "password", "pwd":
+				for _, v := range vs {
+					if v != "" {
+						secrets = append(secrets, v, url.QueryEscape(v))
+					}
+				}
+			}
+		}
+	}
+
+	// replace longer secrets first, as they may contain the shorter forms
+	sort.Slice(secrets, func(i, j int) bool {
+		return len(secrets[i]) > len(secrets[j])
+	})
+
+	return secrets
+}
+
+// scrub replaces any credentials of the data source in s. As this is a plain
+// string replacement, a short password may also replace unrelated text, which
+// is preferable to leaking it.
+func (ds dataSource) scrub(s string) string {
+	s = strings.ReplaceAll(s, ds.url, ds.redactedURL())
+
+	for _, secret := range ds.secrets() {
+		s = strings.ReplaceAll(s, secret, redacted)
+	}
+
+	return s
+}
+
+// redactedURL returns the url of the data source with its credentials
+// replaced, so it can be logged or displayed.
+func (ds dataSource) redactedURL() string {
+	switch ds.driver {
+	case driverMySQL:
+		cfg, err := mysql.ParseDSN(ds.url)
+		if err != nil {
+			return redacted
+		}
+
+		if cfg.Passwd == "" {
+			return ds.url
+		}
+
+		return cfg.User + ":" + redacted + strings.TrimPrefix(ds.url, cfg.User+":"+cfg.Passwd)
+	default:
+		u, err := url.Parse(ds.url)
+		if err != nil {
+			return redacted
+		}
+
+		query := u.Query()
+		for k := range query {
+			switch strings.ToLower(k) {
+			case "password", "pwd":
+				query.Set(k, redacted)
+				u.RawQuery = query.Encode()
+			}
+		}
+
+		return u.Redacted()
+	}
+}
+
+// scrubError wraps err so that its message does not contain the credentials of
+// the data source, the original error is still available to errors.Is/As.
+func (ds dataSource) scrubError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &scrubbedError{
+		err: err,
+		msg: ds.scrub(err.Error()),
+	}
+}
+
+type scrubbedError struct {
+	err error
+	msg string
+}
+
+func (e *scrubbedError) Error() string {
+	return e.msg
+}
+
+func (e *scrubbedError) Unwrap() error {
+	return e.err
+}
@@ -0,0 +1,387 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/ialexj/terraform-provider-sql/internal/server"
+)
+
+type resourceDatabase struct {
+	db dbConnector
+}
+
+var (
+	_ server.Resource         = (*resourceDatabase)(nil)
+	_ server.ResourceUpdater  = (*resourceDatabase)(nil)
+	_ server.ResourceReplacer = (*resourceDatabase)(nil)
+	_ server.ResourceImporter = (*resourceDatabase)(nil)
+)
+
+func newResourceDatabase(db dbConnector) (*resourceDatabase, error) {
+	return &resourceDatabase{
+		db: db,
+	}, nil
+}
+
+func (r *resourceDatabase) Schema(ctx context.Context) *tfprotov6.Schema {
+	return &tfprotov6.Schema{
+		Block: &tfprotov6.SchemaBlock{
+			Description: "Manages a database. The statements are run outside of a transaction, on the database of " +
+				"the connection URL. Databases can be imported by name.",
+			DescriptionKind: tfprotov6.StringKindMarkdown,
+			Attributes: []*tfprotov6.SchemaAttribute{
+				urlAttribute(),
+				initStatementsAttribute("SQL statements to run when connecting for this database, after those of the provider."),
+				{
+					Name:            "name",
+					Required:        true,
+					Description:     "The name of the database. Changing it replaces the database.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.String,
+				},
+				{
+					Name:     "owner",
+					Optional: true,
+					Computed: true,
+					Description: "The role or login owning the database, defaults to the connecting one. Not supported " +
+						"by MySQL.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.String,
+				},
+				{
+					Name:     "encoding",
+					Optional: true,
+					Computed: true,
+					Description: "The encoding, or character set for MySQL, of the database. Not supported by SQL " +
+						"Server. Changing it replaces the database for PostgreSQL.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.String,
+				},
+				{
+					Name:     "collation",
+					Optional: true,
+					Computed: true,
+					Description: "The default collation of the database. Changing it replaces the database for " +
+						"PostgreSQL.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.String,
+				},
+				{
+					Name:     "deletion_protection",
+					Optional: true,
+					Computed: true,
+					Description: "Whether destroying the database fails, defaults to `true`. It must be set to `false` " +
+						"and applied before the database can be dropped.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.Bool,
+				},
+				{
+					Name:            "id",
+					Computed:        true,
+					Description:     "The name of the database.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.String,
+				},
+			},
+		},
+	}
+}
+
+func (r *resourceDatabase) Validate(ctx context.Context, config map[string]tftypes.Value) ([]*tfprotov6.Diagnostic, error) {
+	var diags []*tfprotov6.Diagnostic
+
+	if v := config["name"]; v.IsKnown() {
+		var name string
+		err := v.As(&name)
+		if err != nil {
+			return nil, err
+		}
+		if strings.TrimSpace(name) == "" {
+			diags = append(diags, errorDiag("Name cannot be empty.", tftypes.AttributeName("name")))
+		}
+	}
+
+	return diags, nil
+}
+
+func (r *resourceDatabase) PlanCreate(ctx context.Context, proposed map[string]tftypes.Value, config map[string]tftypes.Value) (map[string]tftypes.Value, []*tfprotov6.Diagnostic, error) {
+	planned := r.plan(proposed, config)
+
+	// the defaults of the server are only known once created
+	for _, name := range []string{"owner", "encoding", "collation"} {
+		if config[name].IsNull() {
+			planned[name] = tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+		}
+	}
+
+	return planned, nil, nil
+}
+
+func (r *resourceDatabase) PlanUpdate(ctx context.Context, proposed map[string]tftypes.Value, config map[string]tftypes.Value, prior map[string]tftypes.Value) (map[string]tftypes.Value, []*tfprotov6.Diagnostic, error) {
+	return r.plan(proposed, config), nil, nil
+}
+
+func (r *resourceDatabase) plan(proposed map[string]tftypes.Value, config map[string]tftypes.Value) map[string]tftypes.Value {
+	planned := map[string]tftypes.Value{}
+	for k, v := range proposed {
+		planned[k] = v
+	}
+
+	planned["id"] = config["name"]
+	if config["deletion_protection"].IsNull() {
+		planned["deletion_protection"] = tftypes.NewValue(tftypes.Bool, true)
+	}
+
+	return planned
+}
+
+func (r *resourceDatabase) RequiresReplace(ctx context.Context, planned map[string]tftypes.Value, prior map[string]tftypes.Value) ([]*tftypes.AttributePath, error) {
+	var paths []*tftypes.AttributePath
+	if !planned["name"].Equal(prior["name"]) {
+		paths = append(paths, tftypes.NewAttributePath().WithAttributeName("name"))
+	}
+
+	if !planned["url"].IsFullyKnown() {
+		return paths, nil
+	}
+	ds, err := r.db.GetDataSource(planned["url"])
+	if err != nil {
+		// the url isn't known until the provider is configured
+		return paths, nil
+	}
+	d, ok := ds.dialect().(databaseDialect)
+	if !ok {
+		return paths, nil
+	}
+
+	priorDatabase, err := databaseFromValues(prior)
+	if err != nil {
+		return nil, err
+	}
+	plannedDatabase, err := databaseFromValues(planned)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range d.DatabaseRequiresReplace(priorDatabase, plannedDatabase) {
+		paths = append(paths, tftypes.NewAttributePath().WithAttributeName(name))
+	}
+
+	return paths, nil
+}
+
+func (r *resourceDatabase) Import(ctx context.Context, id string) (map[string]tftypes.Value, []*tfprotov6.Diagnostic, error) {
+	return map[string]tftypes.Value{
+		"name": tftypes.NewValue(tftypes.String, id),
+		"id":   tftypes.NewValue(tftypes.String, id),
+	}, nil, nil
+}
+
+func (r *resourceDatabase) Read(ctx context.Context, current map[string]tftypes.Value) (map[string]tftypes.Value, []*tfprotov6.Diagnostic, error) {
+	ds, db, err := r.db.GetQueryer(ctx, current)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return r.read(ctx, ds, db, current)
+}
+
+func (r *resourceDatabase) read(ctx context.Context, ds dataSource, db dbQueryer, current map[string]tftypes.Value) (map[string]tftypes.Value, []*tfprotov6.Diagnostic, error) {
+	d, err := databaseDialectFor(ds)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	prior, err := databaseFromValues(current)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	actual, err := d.ReadDatabase(ctx, db, prior.Name)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to read database %q: %w", prior.Name, err)
+	}
+	if actual == nil {
+		// the database was dropped outside of Terraform
+		return nil, nil, nil
+	}
+
+	// keep the configured spelling of encodings and collations
+	if equalFoldStringPtr(prior.Encoding, actual.Encoding) {
+		actual.Encoding = prior.Encoding
+	}
+	if equalFoldStringPtr(prior.Collation, actual.Collation) {
+		actual.Collation = prior.Collation
+	}
+
+	state := map[string]tftypes.Value{}
+	for k, v := range current {
+		state[k] = v
+	}
+	state["owner"] = tftypes.NewValue(tftypes.String, actual.Owner)
+	state["encoding"] = tftypes.NewValue(tftypes.String, actual.Encoding)
+	state["collation"] = tftypes.NewValue(tftypes.String, actual.Collation)
+	if state["deletion_protection"].IsNull() {
+		// imported
+		state["deletion_protection"] = tftypes.NewValue(tftypes.Bool, true)
+	}
+
+	return state, nil, nil
+}
+
+func (r *resourceDatabase) Create(ctx context.Context, planned map[string]tftypes.Value, config map[string]tftypes.Value, prior map[string]tftypes.Value) (map[string]tftypes.Value, []*tfprotov6.Diagnostic, error) {
+	ds, db, err := r.db.GetQueryExecer(ctx, config)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	d, err := databaseDialectFor(ds)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	created, err := databaseFromValues(planned)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stmts, err := d.CreateDatabase(created)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = execStatements(ctx, db, stmts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	state, diags, err := r.read(ctx, ds, db, planned)
+	if err != nil {
+		return nil, nil, err
+	}
+	if state == nil {
+		return nil, nil, fmt.Errorf("database %q was not found after creating it", created.Name)
+	}
+	return state, diags, nil
+}
+
+func (r *resourceDatabase) Update(ctx context.Context, planned map[string]tftypes.Value, config map[string]tftypes.Value, prior map[string]tftypes.Value) (map[string]tftypes.Value, []*tfprotov6.Diagnostic, error) {
+	ds, db, err := r.db.GetExecer(ctx, config)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	d, err := databaseDialectFor(ds)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	priorDatabase, err := databaseFromValues(prior)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	plannedDatabase, err := databaseFromValues(planned)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stmts, err := d.AlterDatabase(priorDatabase, plannedDatabase)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = execStatements(ctx, db, stmts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return planned, nil, nil
+}
+
+func (r *resourceDatabase) Destroy(ctx context.Context, prior map[string]tftypes.Value) ([]*tfprotov6.Diagnostic, error) {
+	var name string
+	err := prior["name"].As(&name)
+	if err != nil {
+		return nil, err
+	}
+
+	var protected bool
+	err = prior["deletion_protection"].As(&protected)
+	if err != nil {
+		return nil, err
+	}
+	if protected {
+		return []*tfprotov6.Diagnostic{
+			{
+				Severity: tfprotov6.DiagnosticSeverityError,
+				Summary:  fmt.Sprintf("Database %q is protected from deletion.", name),
+				Detail:   "Set deletion_protection to false and apply the change before destroying the database.",
+			},
+		}, nil
+	}
+
+	ds, db, err := r.db.GetExecer(ctx, prior)
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := databaseDialectFor(ds)
+	if err != nil {
+		return nil, err
+	}
+
+	err = execStatements(ctx, db, []string{d.DropDatabase(name)})
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func databaseDialectFor(ds dataSource) (databaseDialect, error) {
+	d, ok := ds.dialect().(databaseDialect)
+	if !ok {
+		return nil, fmt.Errorf("databases are not supported for the %s driver", ds.driver)
+	}
+	return d, nil
+}
+
+// databaseFromValues reads the database from a plan or state, options that
+// are not yet known are nil.
+func databaseFromValues(values map[string]tftypes.Value) (database, error) {
+	var d database
+
+	err := values["name"].As(&d.Name)
+	if err != nil {
+		return d, err
+	}
+	d.Owner, err = knownStringFromValue(values["owner"])
+	if err != nil {
+		return d, err
+	}
+	d.Encoding, err = knownStringFromValue(values["encoding"])
+	if err != nil {
+		return d, err
+	}
+	d.Collation, err = knownStringFromValue(values["collation"])
+	if err != nil {
+		return d, err
+	}
+
+	return d, nil
+}
+
+// knownStringFromValue returns nil for a null or unknown string.
+func knownStringFromValue(v tftypes.Value) (*string, error) {
+	if !v.IsKnown() {
+		return nil, nil
+	}
+	var s *string
+	err := v.As(&s)
+	return s, err
+}
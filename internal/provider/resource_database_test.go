@@ -0,0 +1,64 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	helper "github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestResourceDatabase(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping long test")
+	}
+
+	for _, server := range testServers {
+		t.Run(server.ServerType, func(t *testing.T) {
+			url, _, err := server.URL()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			config := func(deletionProtection bool) string {
+				return fmt.Sprintf(`
+provider "sql" {
+	url = %q
+}
+
+resource "sql_database" "test" {
+	name                = "tf_database_test"
+	deletion_protection = %t
+}
+`, url, deletionProtection)
+			}
+
+			helper.UnitTest(t, helper.TestCase{
+				ProtoV6ProviderFactories: protoV6ProviderFactories,
+				Steps: []helper.TestStep{
+					{
+						Config: config(true),
+						Check: helper.ComposeTestCheckFunc(
+							helper.TestCheckResourceAttr("sql_database.test", "id", "tf_database_test"),
+							helper.TestCheckResourceAttrSet("sql_database.test", "collation"),
+						),
+					},
+					{
+						ResourceName:      "sql_database.test",
+						ImportState:       true,
+						ImportStateId:     "tf_database_test",
+						ImportStateVerify: true,
+					},
+					{
+						Config:      config(true),
+						Destroy:     true,
+						ExpectError: regexp.MustCompile("protected from deletion"),
+					},
+					{
+						Config: config(false),
+					},
+				},
+			})
+		})
+	}
+}
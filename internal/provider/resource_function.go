@@ -0,0 +1,278 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/ialexj/terraform-provider-sql/internal/server"
+)
+
+type resourceFunction struct {
+	db dbConnector
+}
+
+var (
+	_ server.Resource         = (*resourceFunction)(nil)
+	_ server.ResourceUpdater  = (*resourceFunction)(nil)
+	_ server.ResourceReplacer = (*resourceFunction)(nil)
+)
+
+func newResourceFunction(db dbConnector) (*resourceFunction, error) {
+	return &resourceFunction{
+		db: db,
+	}, nil
+}
+
+func (r *resourceFunction) Schema(ctx context.Context) *tfprotov6.Schema {
+	return &tfprotov6.Schema{
+		Block: &tfprotov6.SchemaBlock{
+			Description: "Manages a stored function. The function is replaced in place where the database supports " +
+				"it, and dropped and created again for MySQL. Changes made outside of Terraform are detected by " +
+				"comparing the definition stored by the database.",
+			DescriptionKind: tfprotov6.StringKindMarkdown,
+			Attributes: []*tfprotov6.SchemaAttribute{
+				urlAttribute(),
+				initStatementsAttribute("SQL statements to run when connecting for this function, after those of the provider."),
+				{
+					Name:            "schema",
+					Optional:        true,
+					Description:     "The schema of the function, defaults to the current schema.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.String,
+				},
+				{
+					Name:            "name",
+					Required:        true,
+					Description:     "The name of the function. Changing it replaces the function.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.String,
+				},
+				{
+					Name:     "arguments",
+					Optional: true,
+					Description: "The arguments as written in the signature, such as `a integer, b integer`. Changing " +
+						"them replaces the function.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.String,
+				},
+				{
+					Name:            "returns",
+					Required:        true,
+					Description:     "The return type. Changing it replaces the function.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.String,
+				},
+				{
+					Name:     "language",
+					Optional: true,
+					Description: "The language of the body, defaults to `sql`. Only supported by PostgreSQL, other " +
+						"databases take characteristics as part of the body.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.String,
+				},
+				{
+					Name:     "body",
+					Required: true,
+					Description: "The body of the function. For PostgreSQL this is the function source, which is " +
+						"quoted by the provider. For MySQL and SQL Server this is everything after the return type, " +
+						"such as `DETERMINISTIC RETURN a + b` or `BEGIN RETURN @a + @b END`.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.String,
+				},
+				{
+					Name:     "normalized_definition",
+					Computed: true,
+					Description: "The definition as stored by the database, which may be formatted differently. It is " +
+						"used to detect changes made outside of Terraform.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.String,
+				},
+				{
+					Name:            "id",
+					Computed:        true,
+					Description:     "The schema qualified name of the function.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.String,
+				},
+			},
+		},
+	}
+}
+
+func (r *resourceFunction) Validate(ctx context.Context, config map[string]tftypes.Value) ([]*tfprotov6.Diagnostic, error) {
+	return nil, nil
+}
+
+func (r *resourceFunction) PlanCreate(ctx context.Context, proposed map[string]tftypes.Value, config map[string]tftypes.Value) (map[string]tftypes.Value, []*tfprotov6.Diagnostic, error) {
+	planned, err := planDefinition(proposed, config, nil)
+	return planned, nil, err
+}
+
+func (r *resourceFunction) PlanUpdate(ctx context.Context, proposed map[string]tftypes.Value, config map[string]tftypes.Value, prior map[string]tftypes.Value) (map[string]tftypes.Value, []*tfprotov6.Diagnostic, error) {
+	planned, err := planDefinition(proposed, config, prior, "language", "body")
+	return planned, nil, err
+}
+
+func (r *resourceFunction) RequiresReplace(ctx context.Context, planned map[string]tftypes.Value, prior map[string]tftypes.Value) ([]*tftypes.AttributePath, error) {
+	var paths []*tftypes.AttributePath
+	for _, name := range []string{"schema", "name", "arguments", "returns"} {
+		if !planned[name].Equal(prior[name]) {
+			paths = append(paths, tftypes.NewAttributePath().WithAttributeName(name))
+		}
+	}
+	return paths, nil
+}
+
+func (r *resourceFunction) Read(ctx context.Context, current map[string]tftypes.Value) (map[string]tftypes.Value, []*tfprotov6.Diagnostic, error) {
+	ds, db, err := r.db.GetQueryer(ctx, current)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	d, err := functionDialectFor(ds)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	f, err := functionFromValues(current)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	actual, err := d.ReadFunction(ctx, db, f)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to read function %q: %w", f.Name, err)
+	}
+	if actual == nil {
+		// the function was dropped outside of Terraform
+		return nil, nil, nil
+	}
+
+	return readDefinitionState(current, *actual, "body")
+}
+
+func (r *resourceFunction) Create(ctx context.Context, planned map[string]tftypes.Value, config map[string]tftypes.Value, prior map[string]tftypes.Value) (map[string]tftypes.Value, []*tfprotov6.Diagnostic, error) {
+	return r.apply(ctx, planned, config)
+}
+
+func (r *resourceFunction) Update(ctx context.Context, planned map[string]tftypes.Value, config map[string]tftypes.Value, prior map[string]tftypes.Value) (map[string]tftypes.Value, []*tfprotov6.Diagnostic, error) {
+	return r.apply(ctx, planned, config)
+}
+
+// apply creates or replaces the function, and stores the definition read back
+// from the database.
+func (r *resourceFunction) apply(ctx context.Context, planned map[string]tftypes.Value, config map[string]tftypes.Value) (map[string]tftypes.Value, []*tfprotov6.Diagnostic, error) {
+	ds, db, err := r.db.GetQueryExecer(ctx, config)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	d, err := functionDialectFor(ds)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	f, err := functionFromValues(planned)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stmts, err := d.CreateFunction(f)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = execStatements(ctx, db, stmts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	actual, err := d.ReadFunction(ctx, db, f)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to read function %q: %w", f.Name, err)
+	}
+	if actual == nil {
+		return nil, nil, fmt.Errorf("function %q was not found after creating it", f.Name)
+	}
+
+	state := map[string]tftypes.Value{}
+	for k, v := range planned {
+		state[k] = v
+	}
+	state["normalized_definition"] = tftypes.NewValue(tftypes.String, *actual)
+
+	return state, nil, nil
+}
+
+func (r *resourceFunction) Destroy(ctx context.Context, prior map[string]tftypes.Value) ([]*tfprotov6.Diagnostic, error) {
+	ds, db, err := r.db.GetExecer(ctx, prior)
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := functionDialectFor(ds)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := functionFromValues(prior)
+	if err != nil {
+		return nil, err
+	}
+
+	err = execStatements(ctx, db, []string{d.DropFunction(f)})
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func functionDialectFor(ds dataSource) (functionDialect, error) {
+	d, ok := ds.dialect().(functionDialect)
+	if !ok {
+		return nil, fmt.Errorf("functions are not supported for the %s driver", ds.driver)
+	}
+	return d, nil
+}
+
+func functionFromValues(values map[string]tftypes.Value) (function, error) {
+	var f function
+
+	var schema, arguments *string
+	err := values["schema"].As(&schema)
+	if err != nil {
+		return f, err
+	}
+	if schema != nil {
+		f.Schema = *schema
+	}
+	err = values["arguments"].As(&arguments)
+	if err != nil {
+		return f, err
+	}
+	if arguments != nil {
+		f.Arguments = *arguments
+	}
+	err = values["name"].As(&f.Name)
+	if err != nil {
+		return f, err
+	}
+	err = values["returns"].As(&f.Returns)
+	if err != nil {
+		return f, err
+	}
+	err = values["language"].As(&f.Language)
+	if err != nil {
+		return f, err
+	}
+	err = values["body"].As(&f.Body)
+	if err != nil {
+		return f, err
+	}
+
+	return f, nil
+}
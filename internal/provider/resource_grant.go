@@ -0,0 +1,346 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/ialexj/terraform-provider-sql/internal/server"
+)
+
+type resourceGrant struct {
+	db dbConnector
+}
+
+var (
+	_ server.Resource         = (*resourceGrant)(nil)
+	_ server.ResourceUpdater  = (*resourceGrant)(nil)
+	_ server.ResourceReplacer = (*resourceGrant)(nil)
+)
+
+func newResourceGrant(db dbConnector) (*resourceGrant, error) {
+	return &resourceGrant{
+		db: db,
+	}, nil
+}
+
+func (r *resourceGrant) Schema(ctx context.Context) *tfprotov6.Schema {
+	return &tfprotov6.Schema{
+		Block: &tfprotov6.SchemaBlock{
+			Description: "Manages the privileges of a role on tables or schemas. Only the GRANT and REVOKE statements " +
+				"needed to reach the configured privileges are run, and privileges granted outside of Terraform on " +
+				"the objects are revoked.",
+			DescriptionKind: tfprotov6.StringKindMarkdown,
+			Attributes: []*tfprotov6.SchemaAttribute{
+				urlAttribute(),
+				initStatementsAttribute("SQL statements to run when connecting for this grant, after those of the provider."),
+				{
+					Name:            "role",
+					Required:        true,
+					Description:     "The role the privileges are granted to.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.String,
+				},
+				{
+					Name:     "object_type",
+					Required: true,
+					Description: "The type of the objects, either `table` for tables and views, or `schema`. MySQL " +
+						"schema privileges apply to all tables of the database.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.String,
+				},
+				{
+					Name:     "schema",
+					Optional: true,
+					Description: "The schema of the tables, defaults to the current schema. Not supported for " +
+						"`schema` grants.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.String,
+				},
+				{
+					Name:            "objects",
+					Required:        true,
+					Description:     "The names of the tables or schemas.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.Set{ElementType: tftypes.String},
+				},
+				{
+					Name:     "privileges",
+					Required: true,
+					Description: "The privileges to grant on each of the objects, for example `SELECT` or `USAGE`. " +
+						"Privileges are compared case insensitively. `ALL` is compared as the individual privileges it " +
+						"grants, which SQL Server only supports for tables.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.Set{ElementType: tftypes.String},
+				},
+				{
+					Name:            "with_grant_option",
+					Optional:        true,
+					Computed:        true,
+					Description:     "Whether the role can grant the privileges to others, defaults to `false`.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.Bool,
+				},
+				{
+					Name:            "id",
+					Computed:        true,
+					Description:     "The role, object type and schema of the grant.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.String,
+				},
+			},
+		},
+	}
+}
+
+func (r *resourceGrant) Validate(ctx context.Context, config map[string]tftypes.Value) ([]*tfprotov6.Diagnostic, error) {
+	var diags []*tfprotov6.Diagnostic
+
+	if v := config["object_type"]; v.IsKnown() {
+		var objectType string
+		err := v.As(&objectType)
+		if err != nil {
+			return nil, err
+		}
+
+		valid := false
+		for _, t := range grantObjectTypes {
+			valid = valid || objectType == t
+		}
+		switch {
+		case !valid:
+			diags = append(diags, errorDiag(
+				fmt.Sprintf("Unsupported object type %q, expected one of: %s.", objectType, strings.Join(grantObjectTypes, ", ")),
+				tftypes.AttributeName("object_type")))
+		case objectType == grantObjectSchema && !config["schema"].IsNull():
+			diags = append(diags, errorDiag("schema cannot be set for schema grants, list the schemas in objects.", tftypes.AttributeName("schema")))
+		}
+	}
+
+	for _, name := range []string{"objects", "privileges"} {
+		v := config[name]
+		if !v.IsFullyKnown() {
+			continue
+		}
+		strs, err := stringsFromValue(v)
+		if err != nil {
+			return nil, err
+		}
+		if len(strs) == 0 {
+			diags = append(diags, errorDiag(fmt.Sprintf("At least one of %s must be set.", name), tftypes.AttributeName(name)))
+		}
+	}
+
+	return diags, nil
+}
+
+func (r *resourceGrant) PlanCreate(ctx context.Context, proposed map[string]tftypes.Value, config map[string]tftypes.Value) (map[string]tftypes.Value, []*tfprotov6.Diagnostic, error) {
+	return r.plan(ctx, proposed, config)
+}
+
+func (r *resourceGrant) PlanUpdate(ctx context.Context, proposed map[string]tftypes.Value, config map[string]tftypes.Value, prior map[string]tftypes.Value) (map[string]tftypes.Value, []*tfprotov6.Diagnostic, error) {
+	return r.plan(ctx, proposed, config)
+}
+
+func (r *resourceGrant) plan(ctx context.Context, proposed map[string]tftypes.Value, config map[string]tftypes.Value) (map[string]tftypes.Value, []*tfprotov6.Diagnostic, error) {
+	planned := map[string]tftypes.Value{}
+	for k, v := range proposed {
+		planned[k] = v
+	}
+
+	if config["with_grant_option"].IsNull() {
+		planned["with_grant_option"] = tftypes.NewValue(tftypes.Bool, false)
+	}
+
+	planned["id"] = tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+	if config["role"].IsKnown() && config["object_type"].IsKnown() && config["schema"].IsKnown() {
+		var role, objectType string
+		var schema *string
+		err := config["role"].As(&role)
+		if err != nil {
+			return nil, nil, err
+		}
+		err = config["object_type"].As(&objectType)
+		if err != nil {
+			return nil, nil, err
+		}
+		err = config["schema"].As(&schema)
+		if err != nil {
+			return nil, nil, err
+		}
+		planned["id"] = tftypes.NewValue(tftypes.String, grantID(role, objectType, schema))
+	}
+
+	return planned, nil, nil
+}
+
+func (r *resourceGrant) RequiresReplace(ctx context.Context, planned map[string]tftypes.Value, prior map[string]tftypes.Value) ([]*tftypes.AttributePath, error) {
+	var paths []*tftypes.AttributePath
+	for _, name := range []string{"role", "object_type", "schema"} {
+		if !planned[name].Equal(prior[name]) {
+			paths = append(paths, tftypes.NewAttributePath().WithAttributeName(name))
+		}
+	}
+	return paths, nil
+}
+
+func (r *resourceGrant) Read(ctx context.Context, current map[string]tftypes.Value) (map[string]tftypes.Value, []*tfprotov6.Diagnostic, error) {
+	ds, db, err := r.db.GetQueryer(ctx, current)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	d, err := grantDialectFor(ds)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	prior, err := grantFromValues(current)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	actual, err := d.ReadGrants(ctx, db, prior.Role, prior.ObjectType, prior.Schema)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to read the privileges of %q: %w", prior.Role, err)
+	}
+
+	g := readGrantState(d, prior, actual)
+
+	state := map[string]tftypes.Value{}
+	for k, v := range current {
+		state[k] = v
+	}
+	state["privileges"] = stringSetValue(g.Privileges)
+	state["with_grant_option"] = tftypes.NewValue(tftypes.Bool, g.WithGrantOption)
+
+	return state, nil, nil
+}
+
+func (r *resourceGrant) Create(ctx context.Context, planned map[string]tftypes.Value, config map[string]tftypes.Value, prior map[string]tftypes.Value) (map[string]tftypes.Value, []*tfprotov6.Diagnostic, error) {
+	g, err := grantFromValues(planned)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = r.apply(ctx, config, grant{}, g)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return planned, nil, nil
+}
+
+func (r *resourceGrant) Update(ctx context.Context, planned map[string]tftypes.Value, config map[string]tftypes.Value, prior map[string]tftypes.Value) (map[string]tftypes.Value, []*tfprotov6.Diagnostic, error) {
+	priorGrant, err := grantFromValues(prior)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	plannedGrant, err := grantFromValues(planned)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = r.apply(ctx, config, priorGrant, plannedGrant)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return planned, nil, nil
+}
+
+func (r *resourceGrant) Destroy(ctx context.Context, prior map[string]tftypes.Value) ([]*tfprotov6.Diagnostic, error) {
+	g, err := grantFromValues(prior)
+	if err != nil {
+		return nil, err
+	}
+
+	// revoke the prior privileges from all of the objects
+	planned := g
+	planned.Objects = nil
+	planned.Privileges = nil
+
+	err = r.apply(ctx, prior, g, planned)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// apply reads the actual privileges and runs the statements to go from them
+// to the planned grant.
+func (r *resourceGrant) apply(ctx context.Context, values map[string]tftypes.Value, prior, planned grant) error {
+	ds, db, err := r.db.GetQueryExecer(ctx, values)
+	if err != nil {
+		return err
+	}
+
+	d, err := grantDialectFor(ds)
+	if err != nil {
+		return err
+	}
+
+	actual, err := d.ReadGrants(ctx, db, planned.Role, planned.ObjectType, planned.Schema)
+	if err != nil {
+		return fmt.Errorf("unable to read the privileges of %q: %w", planned.Role, err)
+	}
+
+	return execStatements(ctx, db, grantStatements(d, prior, planned, actual))
+}
+
+func grantDialectFor(ds dataSource) (grantDialect, error) {
+	d, ok := ds.dialect().(grantDialect)
+	if !ok {
+		return nil, fmt.Errorf("grants are not supported for the %s driver", ds.driver)
+	}
+	return d, nil
+}
+
+func grantID(role, objectType string, schema *string) string {
+	id := role + "/" + objectType
+	if schema != nil {
+		id += "/" + *schema
+	}
+	return id
+}
+
+// grantFromValues reads the grant from a fully known plan or state.
+func grantFromValues(values map[string]tftypes.Value) (grant, error) {
+	var g grant
+
+	err := values["role"].As(&g.Role)
+	if err != nil {
+		return g, err
+	}
+	err = values["object_type"].As(&g.ObjectType)
+	if err != nil {
+		return g, err
+	}
+	var schema *string
+	err = values["schema"].As(&schema)
+	if err != nil {
+		return g, err
+	}
+	if schema != nil {
+		g.Schema = *schema
+	}
+	g.Objects, err = stringsFromValue(values["objects"])
+	if err != nil {
+		return g, err
+	}
+	g.Privileges, err = stringsFromValue(values["privileges"])
+	if err != nil {
+		return g, err
+	}
+	err = values["with_grant_option"].As(&g.WithGrantOption)
+	if err != nil {
+		return g, err
+	}
+
+	return g, nil
+}
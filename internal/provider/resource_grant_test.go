@@ -0,0 +1,72 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	helper "github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestResourceGrant(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping long test")
+	}
+
+	for _, server := range testServers {
+		t.Run(server.ServerType, func(t *testing.T) {
+			url, _, err := server.URL()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			config := func(privileges string) string {
+				return fmt.Sprintf(`
+provider "sql" {
+	url = %q
+}
+
+resource "sql_role" "test" {
+	name = "tf_grant_test"
+}
+
+resource "sql_table" "test" {
+	name        = "tf_grant_test"
+	primary_key = ["id"]
+
+	column {
+		name = "id"
+		type = "int"
+	}
+}
+
+resource "sql_grant" "test" {
+	role        = sql_role.test.name
+	object_type = "table"
+	objects     = [sql_table.test.name]
+	privileges  = %s
+}
+`, url, privileges)
+			}
+
+			helper.UnitTest(t, helper.TestCase{
+				ProtoV6ProviderFactories: protoV6ProviderFactories,
+				Steps: []helper.TestStep{
+					{
+						Config: config(`["SELECT"]`),
+						Check: helper.ComposeTestCheckFunc(
+							helper.TestCheckResourceAttr("sql_grant.test", "id", "tf_grant_test/table"),
+							helper.TestCheckResourceAttr("sql_grant.test", "privileges.#", "1"),
+							helper.TestCheckResourceAttr("sql_grant.test", "with_grant_option", "false"),
+						),
+					},
+					{
+						Config: config(`["select", "insert", "update"]`),
+						Check: helper.ComposeTestCheckFunc(
+							helper.TestCheckResourceAttr("sql_grant.test", "privileges.#", "3"),
+						),
+					},
+				},
+			})
+		})
+	}
+}
@@ -2,9 +2,11 @@ package provider
 
 import (
 	"context"
+	"database/sql"
 
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/ialexj/terraform-provider-sql/internal/migration"
 )
@@ -28,20 +30,83 @@ func completeMigrationsAttribute() *tfprotov6.SchemaAttribute {
 	}
 }
 
+// batchExecer executes each batch of a migration script separately, as split by
+// the dialect.
+type batchExecer struct {
+	dbExecer
+	dialect Dialect
+}
+
+func (e batchExecer) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	if e.dialect == nil {
+		return e.dbExecer.ExecContext(ctx, query, args...)
+	}
+
+	var result sql.Result
+	for _, batch := range e.dialect.SplitStatements(query) {
+		var err error
+		result, err = e.dbExecer.ExecContext(ctx, batch, args...)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
 type resourceMigrateCommon struct {
 	db dbConnector
 }
 
+// migrationLockName is the name of the lock held while running migrations.
+const migrationLockName = "terraform-provider-sql-migrate"
+
+// lockedExecer connects and takes the migration lock on a single connection,
+// so that concurrent applies against the same database run their migrations
+// one at a time. The returned func releases the lock and the connection.
+func (r *resourceMigrateCommon) lockedExecer(ctx context.Context, values map[string]tftypes.Value) (dbExecer, func(), error) {
+	ds, execer, err := r.db.GetExecer(ctx, values)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	d := ds.dialect()
+	db, ok := execer.(*sql.DB)
+	if d == nil || !ok {
+		return batchExecer{dbExecer: execer, dialect: d}, func() {}, nil
+	}
+
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	unlock, err := d.AcquireLock(ctx, conn, migrationLockName)
+	if err != nil {
+		conn.Close()
+		return nil, nil, err
+	}
+
+	release := func() {
+		if err := unlock(ctx); err != nil {
+			tflog.Warn(ctx, "Unable to release the migration lock.", map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+		conn.Close()
+	}
+	return batchExecer{dbExecer: conn, dialect: d}, release, nil
+}
+
 func (r *resourceMigrateCommon) Read(ctx context.Context, current map[string]tftypes.Value) (map[string]tftypes.Value, []*tfprotov6.Diagnostic, error) {
 	// roundtrip current state as the source of applied migrations
 	return current, nil, nil
 }
 
 func (r *resourceMigrateCommon) Create(ctx context.Context, planned map[string]tftypes.Value, config map[string]tftypes.Value, prior map[string]tftypes.Value) (map[string]tftypes.Value, []*tfprotov6.Diagnostic, error) {
-	_, execer, err := r.db.GetExecer(ctx, config["url"])
+	execer, release, err := r.lockedExecer(ctx, config)
 	if err != nil {
 		return nil, nil, err
 	}
+	defer release()
 
 	plannedMigrations, err := migration.FromListValue(planned["complete_migrations"])
 	if err != nil {
@@ -57,10 +122,11 @@ func (r *resourceMigrateCommon) Create(ctx context.Context, planned map[string]t
 }
 
 func (r *resourceMigrateCommon) Update(ctx context.Context, planned map[string]tftypes.Value, config map[string]tftypes.Value, prior map[string]tftypes.Value) (map[string]tftypes.Value, []*tfprotov6.Diagnostic, error) {
-	_, execer, err := r.db.GetExecer(ctx, config["url"])
+	execer, release, err := r.lockedExecer(ctx, config)
 	if err != nil {
 		return nil, nil, err
 	}
+	defer release()
 
 	priorCompleteMigrations, err := migration.FromListValue(prior["complete_migrations"])
 	if err != nil {
@@ -81,10 +147,11 @@ func (r *resourceMigrateCommon) Update(ctx context.Context, planned map[string]t
 }
 
 func (r *resourceMigrateCommon) Destroy(ctx context.Context, prior map[string]tftypes.Value) ([]*tfprotov6.Diagnostic, error) {
-	_, execer, err := r.db.GetExecer(ctx, prior["url"])
+	execer, release, err := r.lockedExecer(ctx, prior)
 	if err != nil {
 		return nil, err
 	}
+	defer release()
 
 	priorCompleteMigrations, err := migration.FromListValue(prior["complete_migrations"])
 	if err != nil {
@@ -1,10 +1,13 @@
 package provider
 
 import (
+	"context"
+	"database/sql"
 	"fmt"
 	"strings"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	helper "github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
@@ -105,3 +108,48 @@ func TestResourceMigrate(t *testing.T) {
 		})
 	}
 }
+
+// recordingExecer records the executed statements.
+type recordingExecer struct {
+	queries []string
+}
+
+func (e *recordingExecer) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	e.queries = append(e.queries, query)
+	return nil, nil
+}
+
+func TestBatchExecer(t *testing.T) {
+	for name, c := range map[string]struct {
+		dialect  Dialect
+		script   string
+		expected []string
+	}{
+		"multiple batches": {
+			sqlServerDialect{},
+			"CREATE TABLE test (id int)\nGO\nCREATE VIEW test_view AS SELECT id FROM test\nGO",
+			[]string{"CREATE TABLE test (id int)", "CREATE VIEW test_view AS SELECT id FROM test"},
+		},
+		"GO in a string literal": {
+			sqlServerDialect{},
+			"INSERT INTO test (name) VALUES ('ready\nGO\n')",
+			[]string{"INSERT INTO test (name) VALUES ('ready\nGO\n')"},
+		},
+		"pgx": {
+			postgresDialect{},
+			"CREATE TABLE test (id int);\nGO\nSELECT 1",
+			[]string{"CREATE TABLE test (id int);\nGO\nSELECT 1"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			recorder := &recordingExecer{}
+			_, err := batchExecer{dbExecer: recorder, dialect: c.dialect}.ExecContext(context.Background(), c.script)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(c.expected, recorder.queries); diff != "" {
+				t.Fatalf("unexpected statements (-expected +actual):\n%s", diff)
+			}
+		})
+	}
+}
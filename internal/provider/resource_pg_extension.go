@@ -0,0 +1,288 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/ialexj/terraform-provider-sql/internal/server"
+)
+
+type resourcePgExtension struct {
+	db dbConnector
+}
+
+var (
+	_ server.Resource         = (*resourcePgExtension)(nil)
+	_ server.ResourceUpdater  = (*resourcePgExtension)(nil)
+	_ server.ResourceReplacer = (*resourcePgExtension)(nil)
+	_ server.ResourceImporter = (*resourcePgExtension)(nil)
+)
+
+func newResourcePgExtension(db dbConnector) (*resourcePgExtension, error) {
+	return &resourcePgExtension{
+		db: db,
+	}, nil
+}
+
+func (r *resourcePgExtension) Schema(ctx context.Context) *tfprotov6.Schema {
+	return &tfprotov6.Schema{
+		Block: &tfprotov6.SchemaBlock{
+			Description: "Manages a PostgreSQL extension, such as `pgcrypto` or `postgis`. Only supported by the " +
+				"`pgx` driver. Extensions can be imported by name.",
+			DescriptionKind: tfprotov6.StringKindMarkdown,
+			Attributes: []*tfprotov6.SchemaAttribute{
+				urlAttribute(),
+				initStatementsAttribute("SQL statements to run when connecting for this extension, after those of the provider."),
+				{
+					Name:            "name",
+					Required:        true,
+					Description:     "The name of the extension. Changing it replaces the extension.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.String,
+				},
+				{
+					Name:     "schema",
+					Optional: true,
+					Computed: true,
+					Description: "The schema of the objects of the extension, defaults to the current schema. " +
+						"Changing it moves the extension, if the extension supports it.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.String,
+				},
+				{
+					Name:     "version",
+					Optional: true,
+					Computed: true,
+					Description: "The version of the extension, defaults to the default version of the extension. " +
+						"Changing it runs `ALTER EXTENSION ... UPDATE TO`.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.String,
+				},
+				{
+					Name:            "id",
+					Computed:        true,
+					Description:     "The name of the extension.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.String,
+				},
+			},
+		},
+	}
+}
+
+func (r *resourcePgExtension) Validate(ctx context.Context, config map[string]tftypes.Value) ([]*tfprotov6.Diagnostic, error) {
+	return nil, nil
+}
+
+func (r *resourcePgExtension) PlanCreate(ctx context.Context, proposed map[string]tftypes.Value, config map[string]tftypes.Value) (map[string]tftypes.Value, []*tfprotov6.Diagnostic, error) {
+	planned := r.plan(proposed, config)
+
+	// the defaults are only known once created
+	for _, name := range []string{"schema", "version"} {
+		if config[name].IsNull() {
+			planned[name] = tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+		}
+	}
+
+	return planned, r.driverDiags(config), nil
+}
+
+func (r *resourcePgExtension) PlanUpdate(ctx context.Context, proposed map[string]tftypes.Value, config map[string]tftypes.Value, prior map[string]tftypes.Value) (map[string]tftypes.Value, []*tfprotov6.Diagnostic, error) {
+	return r.plan(proposed, config), r.driverDiags(config), nil
+}
+
+func (r *resourcePgExtension) plan(proposed map[string]tftypes.Value, config map[string]tftypes.Value) map[string]tftypes.Value {
+	planned := map[string]tftypes.Value{}
+	for k, v := range proposed {
+		planned[k] = v
+	}
+
+	planned["id"] = config["name"]
+
+	return planned
+}
+
+// driverDiags reports an unsupported driver at plan time, if the url is
+// already known.
+func (r *resourcePgExtension) driverDiags(config map[string]tftypes.Value) []*tfprotov6.Diagnostic {
+	if !r.db.HasUrl() || !config["url"].IsKnown() {
+		return nil
+	}
+	ds, err := r.db.GetDataSource(config["url"])
+	if err != nil {
+		return nil
+	}
+	return pgExtensionDriverDiags(ds)
+}
+
+func (r *resourcePgExtension) RequiresReplace(ctx context.Context, planned map[string]tftypes.Value, prior map[string]tftypes.Value) ([]*tftypes.AttributePath, error) {
+	if !planned["name"].Equal(prior["name"]) {
+		return []*tftypes.AttributePath{tftypes.NewAttributePath().WithAttributeName("name")}, nil
+	}
+	return nil, nil
+}
+
+func (r *resourcePgExtension) Import(ctx context.Context, id string) (map[string]tftypes.Value, []*tfprotov6.Diagnostic, error) {
+	return map[string]tftypes.Value{
+		"name": tftypes.NewValue(tftypes.String, id),
+		"id":   tftypes.NewValue(tftypes.String, id),
+	}, nil, nil
+}
+
+func (r *resourcePgExtension) Read(ctx context.Context, current map[string]tftypes.Value) (map[string]tftypes.Value, []*tfprotov6.Diagnostic, error) {
+	ds, db, err := r.db.GetQueryer(ctx, current)
+	if err != nil {
+		return nil, nil, err
+	}
+	if diags := pgExtensionDriverDiags(ds); diags != nil {
+		return nil, diags, nil
+	}
+
+	return r.read(ctx, db, current)
+}
+
+func (r *resourcePgExtension) read(ctx context.Context, db dbQueryer, current map[string]tftypes.Value) (map[string]tftypes.Value, []*tfprotov6.Diagnostic, error) {
+	var name string
+	err := current["name"].As(&name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	actual, err := readPgExtension(ctx, db, name)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to read extension %q: %w", name, err)
+	}
+	if actual == nil {
+		// the extension was dropped outside of Terraform
+		return nil, nil, nil
+	}
+
+	state := map[string]tftypes.Value{}
+	for k, v := range current {
+		state[k] = v
+	}
+	state["schema"] = tftypes.NewValue(tftypes.String, actual.Schema)
+	state["version"] = tftypes.NewValue(tftypes.String, actual.Version)
+
+	return state, nil, nil
+}
+
+func (r *resourcePgExtension) Create(ctx context.Context, planned map[string]tftypes.Value, config map[string]tftypes.Value, prior map[string]tftypes.Value) (map[string]tftypes.Value, []*tfprotov6.Diagnostic, error) {
+	ds, db, err := r.db.GetQueryExecer(ctx, config)
+	if err != nil {
+		return nil, nil, err
+	}
+	if diags := pgExtensionDriverDiags(ds); diags != nil {
+		return nil, diags, nil
+	}
+
+	created, err := pgExtensionFromValues(planned)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = execStatements(ctx, db, createPgExtension(created))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	state, diags, err := r.read(ctx, db, planned)
+	if err != nil {
+		return nil, nil, err
+	}
+	if state == nil {
+		return nil, nil, fmt.Errorf("extension %q was not found after creating it", created.Name)
+	}
+	return state, diags, nil
+}
+
+func (r *resourcePgExtension) Update(ctx context.Context, planned map[string]tftypes.Value, config map[string]tftypes.Value, prior map[string]tftypes.Value) (map[string]tftypes.Value, []*tfprotov6.Diagnostic, error) {
+	ds, db, err := r.db.GetExecer(ctx, config)
+	if err != nil {
+		return nil, nil, err
+	}
+	if diags := pgExtensionDriverDiags(ds); diags != nil {
+		return nil, diags, nil
+	}
+
+	priorExtension, err := pgExtensionFromValues(prior)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	plannedExtension, err := pgExtensionFromValues(planned)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = execStatements(ctx, db, alterPgExtension(priorExtension, plannedExtension))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return planned, nil, nil
+}
+
+func (r *resourcePgExtension) Destroy(ctx context.Context, prior map[string]tftypes.Value) ([]*tfprotov6.Diagnostic, error) {
+	ds, db, err := r.db.GetExecer(ctx, prior)
+	if err != nil {
+		return nil, err
+	}
+	if diags := pgExtensionDriverDiags(ds); diags != nil {
+		return diags, nil
+	}
+
+	var name string
+	err = prior["name"].As(&name)
+	if err != nil {
+		return nil, err
+	}
+
+	err = execStatements(ctx, db, []string{dropPgExtension(name)})
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// pgExtensionDriverDiags returns an error diagnostic unless the data source
+// uses the PostgreSQL driver.
+func pgExtensionDriverDiags(ds dataSource) []*tfprotov6.Diagnostic {
+	if ds.driver == driverPgx {
+		return nil
+	}
+	return []*tfprotov6.Diagnostic{
+		{
+			Severity: tfprotov6.DiagnosticSeverityError,
+			Summary:  "Extensions are only supported by PostgreSQL",
+			Detail: fmt.Sprintf("sql_pg_extension requires the pgx driver, but the %s driver is configured. "+
+				"Use a postgres:// URL, or remove the resource.", ds.driver),
+			Attribute: tftypes.NewAttributePath().WithAttributeName("url"),
+		},
+	}
+}
+
+// pgExtensionFromValues reads the extension from a plan or state, the schema
+// and version are nil if not yet known.
+func pgExtensionFromValues(values map[string]tftypes.Value) (pgExtension, error) {
+	var e pgExtension
+
+	err := values["name"].As(&e.Name)
+	if err != nil {
+		return e, err
+	}
+	e.Schema, err = knownStringFromValue(values["schema"])
+	if err != nil {
+		return e, err
+	}
+	e.Version, err = knownStringFromValue(values["version"])
+	if err != nil {
+		return e, err
+	}
+
+	return e, nil
+}
@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	helper "github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestResourcePgExtension(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping long test")
+	}
+
+	for _, server := range testServers {
+		t.Run(server.ServerType, func(t *testing.T) {
+			url, _, err := server.URL()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			config := fmt.Sprintf(`
+provider "sql" {
+	url = %q
+}
+
+resource "sql_pg_extension" "test" {
+	name = "pgcrypto"
+}
+`, url)
+
+			switch server.ServerType {
+			case "postgres":
+			case "cockroach":
+				t.Skip("extensions are not supported by cockroach")
+			default:
+				helper.UnitTest(t, helper.TestCase{
+					ProtoV6ProviderFactories: protoV6ProviderFactories,
+					Steps: []helper.TestStep{
+						{
+							Config:      config,
+							ExpectError: regexp.MustCompile("Extensions are only supported by PostgreSQL"),
+						},
+					},
+				})
+				return
+			}
+
+			helper.UnitTest(t, helper.TestCase{
+				ProtoV6ProviderFactories: protoV6ProviderFactories,
+				Steps: []helper.TestStep{
+					{
+						Config: config,
+						Check: helper.ComposeTestCheckFunc(
+							helper.TestCheckResourceAttr("sql_pg_extension.test", "id", "pgcrypto"),
+							helper.TestCheckResourceAttrSet("sql_pg_extension.test", "version"),
+						),
+					},
+					{
+						ResourceName:      "sql_pg_extension.test",
+						ImportState:       true,
+						ImportStateId:     "pgcrypto",
+						ImportStateVerify: true,
+					},
+				},
+			})
+		})
+	}
+}
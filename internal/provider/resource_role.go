@@ -0,0 +1,373 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/ialexj/terraform-provider-sql/internal/server"
+)
+
+type resourceRole struct {
+	db dbConnector
+}
+
+var (
+	_ server.Resource         = (*resourceRole)(nil)
+	_ server.ResourceUpdater  = (*resourceRole)(nil)
+	_ server.ResourceReplacer = (*resourceRole)(nil)
+)
+
+func newResourceRole(db dbConnector) (*resourceRole, error) {
+	return &resourceRole{
+		db: db,
+	}, nil
+}
+
+func (r *resourceRole) Schema(ctx context.Context) *tfprotov6.Schema {
+	return &tfprotov6.Schema{
+		Block: &tfprotov6.SchemaBlock{
+			Description: "Manages a role or user. For PostgreSQL this is a role, for MySQL an account for any host, " +
+				"and for SQL Server a database role, or a login and its database user when `login` is set.",
+			DescriptionKind: tfprotov6.StringKindMarkdown,
+			Attributes: []*tfprotov6.SchemaAttribute{
+				urlAttribute(),
+				initStatementsAttribute("SQL statements to run when connecting for this role, after those of the provider."),
+				{
+					Name:            "name",
+					Required:        true,
+					Description:     "The name of the role. Changing it replaces the role.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.String,
+				},
+				{
+					Name:     "login",
+					Optional: true,
+					Computed: true,
+					Description: "Whether the role can log in, defaults to `false`. Changing it replaces the role for " +
+						"SQL Server, where roles and users are different kinds of principal.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.Bool,
+				},
+				{
+					Name:      "password",
+					Optional:  true,
+					Sensitive: true,
+					Description: "The password of the role. Passwords can't be read back from the database, so only " +
+						"changes to the configured value are applied. It is required for SQL Server logins. The password " +
+						"is stored in plain text in the Terraform state, where it is only marked as sensitive.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.String,
+				},
+				{
+					Name:            "roles",
+					Optional:        true,
+					Description:     "The roles this role is a member of, and inherits the privileges of.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.Set{ElementType: tftypes.String},
+				},
+				{
+					Name:     "connection_limit",
+					Optional: true,
+					Computed: true,
+					Description: "The maximum number of concurrent connections of the role, defaults to `-1` for " +
+						"unlimited. SQL Server does not support connection limits, so it can't be set.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.Number,
+				},
+				{
+					Name:            "id",
+					Computed:        true,
+					Description:     "The name of the role.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.String,
+				},
+			},
+		},
+	}
+}
+
+func (r *resourceRole) Validate(ctx context.Context, config map[string]tftypes.Value) ([]*tfprotov6.Diagnostic, error) {
+	var diags []*tfprotov6.Diagnostic
+
+	if v := config["name"]; v.IsKnown() {
+		var name string
+		err := v.As(&name)
+		if err != nil {
+			return nil, err
+		}
+		if strings.TrimSpace(name) == "" {
+			diags = append(diags, errorDiag("Name cannot be empty.", tftypes.AttributeName("name")))
+		}
+	}
+
+	if v := config["connection_limit"]; v.IsKnown() && !v.IsNull() {
+		limit, err := int64FromValue(v)
+		if err != nil || limit < -1 {
+			diags = append(diags, errorDiag("connection_limit must be an integer of at least -1.", tftypes.AttributeName("connection_limit")))
+		}
+	}
+	diags = append(diags, connectionLimitDriverDiags(configuredDriver(config), config)...)
+
+	return diags, nil
+}
+
+// connectionLimitDriverDiags rejects connection limits for SQL Server, which
+// does not support them.
+func connectionLimitDriverDiags(driver driverName, config map[string]tftypes.Value) []*tfprotov6.Diagnostic {
+	if driver != driverSQLServer && driver != driverAzureSQL {
+		return nil
+	}
+	if v := config["connection_limit"]; v.IsNull() {
+		return nil
+	}
+	return []*tfprotov6.Diagnostic{
+		errorDiag(fmt.Sprintf("connection_limit is not supported for the %s driver.", driver), tftypes.AttributeName("connection_limit")),
+	}
+}
+
+func (r *resourceRole) PlanCreate(ctx context.Context, proposed map[string]tftypes.Value, config map[string]tftypes.Value) (map[string]tftypes.Value, []*tfprotov6.Diagnostic, error) {
+	return r.plan(ctx, proposed, config)
+}
+
+func (r *resourceRole) PlanUpdate(ctx context.Context, proposed map[string]tftypes.Value, config map[string]tftypes.Value, prior map[string]tftypes.Value) (map[string]tftypes.Value, []*tfprotov6.Diagnostic, error) {
+	return r.plan(ctx, proposed, config)
+}
+
+func (r *resourceRole) plan(ctx context.Context, proposed map[string]tftypes.Value, config map[string]tftypes.Value) (map[string]tftypes.Value, []*tfprotov6.Diagnostic, error) {
+	planned := map[string]tftypes.Value{}
+	for k, v := range proposed {
+		planned[k] = v
+	}
+
+	planned["id"] = config["name"]
+	if config["login"].IsNull() {
+		planned["login"] = tftypes.NewValue(tftypes.Bool, false)
+	}
+	if config["connection_limit"].IsNull() {
+		planned["connection_limit"] = tftypes.NewValue(tftypes.Number, -1)
+	}
+
+	// the provider's url is only known once it is configured
+	var diags []*tfprotov6.Diagnostic
+	if config["url"].IsNull() && r.db.HasUrl() {
+		ds, err := r.db.GetDataSource(config["url"])
+		if err == nil {
+			diags = connectionLimitDriverDiags(ds.driver, config)
+		}
+	}
+
+	return planned, diags, nil
+}
+
+func (r *resourceRole) RequiresReplace(ctx context.Context, planned map[string]tftypes.Value, prior map[string]tftypes.Value) ([]*tftypes.AttributePath, error) {
+	var paths []*tftypes.AttributePath
+	if !planned["name"].Equal(prior["name"]) {
+		paths = append(paths, tftypes.NewAttributePath().WithAttributeName("name"))
+	}
+
+	for _, k := range []string{"url", "login", "password", "roles", "connection_limit"} {
+		if !planned[k].IsFullyKnown() {
+			return paths, nil
+		}
+	}
+	ds, err := r.db.GetDataSource(planned["url"])
+	if err != nil {
+		// the url isn't known until the provider is configured
+		return paths, nil
+	}
+	d, ok := ds.dialect().(roleDialect)
+	if !ok {
+		return paths, nil
+	}
+
+	priorRole, err := roleFromValues(prior)
+	if err != nil {
+		return nil, err
+	}
+	plannedRole, err := roleFromValues(planned)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range d.RoleRequiresReplace(priorRole, plannedRole) {
+		paths = append(paths, tftypes.NewAttributePath().WithAttributeName(name))
+	}
+
+	return paths, nil
+}
+
+func (r *resourceRole) Read(ctx context.Context, current map[string]tftypes.Value) (map[string]tftypes.Value, []*tfprotov6.Diagnostic, error) {
+	ds, db, err := r.db.GetQueryer(ctx, current)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	d, err := roleDialectFor(ds)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	prior, err := roleFromValues(current)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	actual, err := d.ReadRole(ctx, db, prior.Name)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to read role %q: %w", prior.Name, err)
+	}
+	if actual == nil {
+		// the role was dropped outside of Terraform
+		return nil, nil, nil
+	}
+
+	state := map[string]tftypes.Value{}
+	for k, v := range current {
+		state[k] = v
+	}
+	state["login"] = tftypes.NewValue(tftypes.Bool, actual.Login)
+	state["connection_limit"] = tftypes.NewValue(tftypes.Number, actual.ConnectionLimit)
+	if len(actual.Roles) > 0 || !current["roles"].IsNull() {
+		state["roles"] = stringSetValue(actual.Roles)
+	}
+
+	return state, nil, nil
+}
+
+func (r *resourceRole) Create(ctx context.Context, planned map[string]tftypes.Value, config map[string]tftypes.Value, prior map[string]tftypes.Value) (map[string]tftypes.Value, []*tfprotov6.Diagnostic, error) {
+	ds, db, err := r.db.GetExecer(ctx, config)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	d, err := roleDialectFor(ds)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	role, err := roleFromValues(planned)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stmts, err := d.CreateRole(role)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = execStatements(ctx, db, stmts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return planned, nil, nil
+}
+
+func (r *resourceRole) Update(ctx context.Context, planned map[string]tftypes.Value, config map[string]tftypes.Value, prior map[string]tftypes.Value) (map[string]tftypes.Value, []*tfprotov6.Diagnostic, error) {
+	ds, db, err := r.db.GetExecer(ctx, config)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	d, err := roleDialectFor(ds)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	priorRole, err := roleFromValues(prior)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	plannedRole, err := roleFromValues(planned)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stmts, err := d.AlterRole(priorRole, plannedRole)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = execStatements(ctx, db, stmts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return planned, nil, nil
+}
+
+func (r *resourceRole) Destroy(ctx context.Context, prior map[string]tftypes.Value) ([]*tfprotov6.Diagnostic, error) {
+	ds, db, err := r.db.GetExecer(ctx, prior)
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := roleDialectFor(ds)
+	if err != nil {
+		return nil, err
+	}
+
+	role, err := roleFromValues(prior)
+	if err != nil {
+		return nil, err
+	}
+
+	err = execStatements(ctx, db, d.DropRole(role))
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func roleDialectFor(ds dataSource) (roleDialect, error) {
+	d, ok := ds.dialect().(roleDialect)
+	if !ok {
+		return nil, fmt.Errorf("roles are not supported for the %s driver", ds.driver)
+	}
+	return d, nil
+}
+
+// roleFromValues reads the role from a fully known plan or state.
+func roleFromValues(values map[string]tftypes.Value) (role, error) {
+	var r role
+
+	err := values["name"].As(&r.Name)
+	if err != nil {
+		return r, err
+	}
+	err = values["login"].As(&r.Login)
+	if err != nil {
+		return r, err
+	}
+	err = values["password"].As(&r.Password)
+	if err != nil {
+		return r, err
+	}
+	r.Roles, err = stringsFromValue(values["roles"])
+	if err != nil {
+		return r, err
+	}
+
+	r.ConnectionLimit = -1
+	if v := values["connection_limit"]; !v.IsNull() {
+		r.ConnectionLimit, err = int64FromValue(v)
+		if err != nil {
+			return r, err
+		}
+	}
+
+	return r, nil
+}
+
+func stringSetValue(strs []string) tftypes.Value {
+	values := make([]tftypes.Value, 0, len(strs))
+	for _, s := range strs {
+		values = append(values, tftypes.NewValue(tftypes.String, s))
+	}
+	return tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, values)
+}
@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	helper "github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestResourceRole_validate(t *testing.T) {
+	r := &resourceRole{}
+
+	for url, valid := range map[string]bool{
+		"postgres://localhost/db":                                      true,
+		"sqlserver://localhost?database=db":                            false,
+		"azuresql://server?database=db&fedauth=ActiveDirectoryDefault": false,
+	} {
+		t.Run(url, func(t *testing.T) {
+			config := map[string]tftypes.Value{
+				"url":              tftypes.NewValue(tftypes.String, url),
+				"name":             tftypes.NewValue(tftypes.String, "app"),
+				"connection_limit": tftypes.NewValue(tftypes.Number, 5),
+			}
+
+			diags, err := r.Validate(context.Background(), config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if (len(diags) == 0) != valid {
+				t.Fatalf("unexpected diags: %v", diags)
+			}
+			if !valid {
+				expected := tftypes.NewAttributePath().WithAttributeName("connection_limit")
+				if !diags[0].Attribute.Equal(expected) {
+					t.Fatalf("expected the diag on %s, got %s", expected, diags[0].Attribute)
+				}
+			}
+		})
+	}
+}
+
+func TestResourceRole(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping long test")
+	}
+
+	for _, server := range testServers {
+		t.Run(server.ServerType, func(t *testing.T) {
+			url, _, err := server.URL()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			config := func(password string, roles string) string {
+				return fmt.Sprintf(`
+provider "sql" {
+	url = %q
+}
+
+resource "sql_role" "reader" {
+	name = "tf_role_test_reader"
+}
+
+resource "sql_role" "test" {
+	name     = "tf_role_test"
+	login    = true
+	password = %q
+	roles    = %s
+}
+`, url, password, roles)
+			}
+
+			helper.UnitTest(t, helper.TestCase{
+				ProtoV6ProviderFactories: protoV6ProviderFactories,
+				Steps: []helper.TestStep{
+					{
+						Config: config("Passw0rd!first", "[]"),
+						Check: helper.ComposeTestCheckFunc(
+							helper.TestCheckResourceAttr("sql_role.test", "id", "tf_role_test"),
+							helper.TestCheckResourceAttr("sql_role.test", "roles.#", "0"),
+							helper.TestCheckResourceAttr("sql_role.test", "connection_limit", "-1"),
+							helper.TestCheckResourceAttr("sql_role.reader", "login", "false"),
+						),
+					},
+					{
+						Config: config("Passw0rd!second", "[sql_role.reader.name]"),
+						Check: helper.ComposeTestCheckFunc(
+							helper.TestCheckResourceAttr("sql_role.test", "roles.#", "1"),
+							helper.TestCheckResourceAttr("sql_role.test", "roles.0", "tf_role_test_reader"),
+						),
+					},
+				},
+			})
+		})
+	}
+}
@@ -0,0 +1,376 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/ialexj/terraform-provider-sql/internal/server"
+)
+
+var tableRowType = tftypes.Map{ElementType: tftypes.String}
+
+type resourceRows struct {
+	db dbConnector
+}
+
+var (
+	_ server.Resource         = (*resourceRows)(nil)
+	_ server.ResourceUpdater  = (*resourceRows)(nil)
+	_ server.ResourceReplacer = (*resourceRows)(nil)
+)
+
+func newResourceRows(db dbConnector) (*resourceRows, error) {
+	return &resourceRows{
+		db: db,
+	}, nil
+}
+
+func (r *resourceRows) Schema(ctx context.Context) *tfprotov6.Schema {
+	return &tfprotov6.Schema{
+		Block: &tfprotov6.SchemaBlock{
+			Description: "Manages rows of a table, such as seed or reference data. Changed rows are upserted and " +
+				"removed rows deleted in a transaction, other rows of the table are left alone.",
+			DescriptionKind: tfprotov6.StringKindMarkdown,
+			Attributes: []*tfprotov6.SchemaAttribute{
+				urlAttribute(),
+				initStatementsAttribute("SQL statements to run when connecting for these rows, after those of the provider."),
+				{
+					Name:            "schema",
+					Optional:        true,
+					Description:     "The schema of the table, defaults to the current schema.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.String,
+				},
+				{
+					Name:            "table",
+					Required:        true,
+					Description:     "The name of the table.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.String,
+				},
+				{
+					Name:     "key_columns",
+					Required: true,
+					Description: "The columns identifying the rows. They must be the primary key or a unique index of " +
+						"the table.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.List{ElementType: tftypes.String},
+				},
+				{
+					Name:     "rows",
+					Required: true,
+					Description: "The rows, as maps of column names to values. Values are strings converted to the " +
+						"type of the column by the database, or `null`. Every row must set the key columns.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.List{ElementType: tableRowType},
+				},
+				{
+					Name:            "id",
+					Computed:        true,
+					Description:     "The schema qualified name of the table.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.String,
+				},
+			},
+		},
+	}
+}
+
+func (r *resourceRows) Validate(ctx context.Context, config map[string]tftypes.Value) ([]*tfprotov6.Diagnostic, error) {
+	var diags []*tfprotov6.Diagnostic
+
+	if !config["key_columns"].IsFullyKnown() {
+		return nil, nil
+	}
+	keyColumns, err := stringsFromValue(config["key_columns"])
+	if err != nil {
+		return nil, err
+	}
+	if len(keyColumns) == 0 {
+		return []*tfprotov6.Diagnostic{
+			errorDiag("At least one key column must be set.", tftypes.AttributeName("key_columns")),
+		}, nil
+	}
+
+	v := config["rows"]
+	if !v.IsKnown() || v.IsNull() {
+		return nil, nil
+	}
+	var values []tftypes.Value
+	err = v.As(&values)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := map[string]bool{}
+	for i, rv := range values {
+		if !rv.IsFullyKnown() {
+			continue
+		}
+		row, err := tableRowFromValue(rv)
+		if err != nil {
+			return nil, err
+		}
+
+		path := []tftypes.AttributePathStep{tftypes.AttributeName("rows"), tftypes.ElementKeyInt(i)}
+		missing := false
+		for _, c := range keyColumns {
+			if row[c] == nil {
+				diags = append(diags, errorDiag(fmt.Sprintf("Key column %q must be set and not null.", c), path...))
+				missing = true
+			}
+		}
+		if missing {
+			continue
+		}
+
+		key := rowKey(row, keyColumns)
+		if keys[key] {
+			diags = append(diags, errorDiag("Duplicate row for the key columns.", path...))
+		}
+		keys[key] = true
+	}
+
+	return diags, nil
+}
+
+func (r *resourceRows) PlanCreate(ctx context.Context, proposed map[string]tftypes.Value, config map[string]tftypes.Value) (map[string]tftypes.Value, []*tfprotov6.Diagnostic, error) {
+	return r.plan(ctx, proposed, config)
+}
+
+func (r *resourceRows) PlanUpdate(ctx context.Context, proposed map[string]tftypes.Value, config map[string]tftypes.Value, prior map[string]tftypes.Value) (map[string]tftypes.Value, []*tfprotov6.Diagnostic, error) {
+	return r.plan(ctx, proposed, config)
+}
+
+func (r *resourceRows) plan(ctx context.Context, proposed map[string]tftypes.Value, config map[string]tftypes.Value) (map[string]tftypes.Value, []*tfprotov6.Diagnostic, error) {
+	planned := map[string]tftypes.Value{}
+	for k, v := range proposed {
+		planned[k] = v
+	}
+
+	planned["id"] = tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+	if config["schema"].IsKnown() && config["table"].IsKnown() {
+		var schema *string
+		var name string
+		err := config["schema"].As(&schema)
+		if err != nil {
+			return nil, nil, err
+		}
+		err = config["table"].As(&name)
+		if err != nil {
+			return nil, nil, err
+		}
+		if schema == nil {
+			planned["id"] = tftypes.NewValue(tftypes.String, tableID("", name))
+		} else {
+			planned["id"] = tftypes.NewValue(tftypes.String, tableID(*schema, name))
+		}
+	}
+
+	return planned, nil, nil
+}
+
+func (r *resourceRows) RequiresReplace(ctx context.Context, planned map[string]tftypes.Value, prior map[string]tftypes.Value) ([]*tftypes.AttributePath, error) {
+	var paths []*tftypes.AttributePath
+	for _, name := range []string{"schema", "table", "key_columns"} {
+		if !planned[name].Equal(prior[name]) {
+			paths = append(paths, tftypes.NewAttributePath().WithAttributeName(name))
+		}
+	}
+	return paths, nil
+}
+
+func (r *resourceRows) Read(ctx context.Context, current map[string]tftypes.Value) (map[string]tftypes.Value, []*tfprotov6.Diagnostic, error) {
+	ds, db, err := r.db.GetQueryer(ctx, current)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	d, err := rowsDialectFor(ds)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	table, keyColumns, prior, err := rowsFromValues(d, current)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// rows deleted outside of Terraform are dropped from the state, so that
+	// they are planned to be inserted again
+	rows := make([]tableRow, 0, len(prior))
+	for _, p := range prior {
+		row, err := readRow(ctx, db, d, table, keyColumns, p)
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to read row of %s: %w", table, err)
+		}
+		if row != nil {
+			rows = append(rows, row)
+		}
+	}
+
+	state := map[string]tftypes.Value{}
+	for k, v := range current {
+		state[k] = v
+	}
+	state["rows"] = tableRowsValue(rows)
+
+	return state, nil, nil
+}
+
+func (r *resourceRows) Create(ctx context.Context, planned map[string]tftypes.Value, config map[string]tftypes.Value, prior map[string]tftypes.Value) (map[string]tftypes.Value, []*tfprotov6.Diagnostic, error) {
+	err := r.apply(ctx, config, nil, planned)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return planned, nil, nil
+}
+
+func (r *resourceRows) Update(ctx context.Context, planned map[string]tftypes.Value, config map[string]tftypes.Value, prior map[string]tftypes.Value) (map[string]tftypes.Value, []*tfprotov6.Diagnostic, error) {
+	err := r.apply(ctx, config, prior, planned)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return planned, nil, nil
+}
+
+func (r *resourceRows) Destroy(ctx context.Context, prior map[string]tftypes.Value) ([]*tfprotov6.Diagnostic, error) {
+	planned := map[string]tftypes.Value{}
+	for k, v := range prior {
+		planned[k] = v
+	}
+	planned["rows"] = tableRowsValue(nil)
+
+	err := r.apply(ctx, prior, prior, planned)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// apply runs the statements to go from the prior to the planned rows in a
+// transaction.
+func (r *resourceRows) apply(ctx context.Context, config map[string]tftypes.Value, prior, planned map[string]tftypes.Value) error {
+	ds, db, err := r.db.GetQueryer(ctx, config)
+	if err != nil {
+		return err
+	}
+
+	d, err := rowsDialectFor(ds)
+	if err != nil {
+		return err
+	}
+
+	table, keyColumns, plannedRows, err := rowsFromValues(d, planned)
+	if err != nil {
+		return err
+	}
+
+	var priorRows []tableRow
+	if prior != nil {
+		_, _, priorRows, err = rowsFromValues(d, prior)
+		if err != nil {
+			return err
+		}
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, stmt := range rowsStatements(d, table, keyColumns, priorRows, plannedRows) {
+		_, err = tx.ExecContext(ctx, stmt.query, stmt.args...)
+		if err != nil {
+			return fmt.Errorf("unable to run %q: %w", stmt.query, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+func rowsDialectFor(ds dataSource) (rowsDialect, error) {
+	d, ok := ds.dialect().(rowsDialect)
+	if !ok {
+		return nil, fmt.Errorf("rows are not supported for the %s driver", ds.driver)
+	}
+	return d, nil
+}
+
+// rowsFromValues reads the quoted table name, the key columns and the rows
+// from a fully known plan or state.
+func rowsFromValues(d Dialect, values map[string]tftypes.Value) (string, []string, []tableRow, error) {
+	var schema *string
+	err := values["schema"].As(&schema)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	var name string
+	err = values["table"].As(&name)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	table := qualifiedName(d, "", name)
+	if schema != nil {
+		table = qualifiedName(d, *schema, name)
+	}
+
+	keyColumns, err := stringsFromValue(values["key_columns"])
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	var rowValues []tftypes.Value
+	err = values["rows"].As(&rowValues)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	rows := make([]tableRow, 0, len(rowValues))
+	for _, rv := range rowValues {
+		row, err := tableRowFromValue(rv)
+		if err != nil {
+			return "", nil, nil, err
+		}
+		rows = append(rows, row)
+	}
+
+	return table, keyColumns, rows, nil
+}
+
+func tableRowFromValue(v tftypes.Value) (tableRow, error) {
+	var values map[string]tftypes.Value
+	err := v.As(&values)
+	if err != nil {
+		return nil, err
+	}
+
+	row := tableRow{}
+	for c, cv := range values {
+		var s *string
+		err = cv.As(&s)
+		if err != nil {
+			return nil, fmt.Errorf("column %q: %w", c, err)
+		}
+		row[c] = s
+	}
+	return row, nil
+}
+
+func tableRowsValue(rows []tableRow) tftypes.Value {
+	values := make([]tftypes.Value, 0, len(rows))
+	for _, row := range rows {
+		columns := map[string]tftypes.Value{}
+		for c, v := range row {
+			columns[c] = tftypes.NewValue(tftypes.String, v)
+		}
+		values = append(values, tftypes.NewValue(tableRowType, columns))
+	}
+	return tftypes.NewValue(tftypes.List{ElementType: tableRowType}, values)
+}
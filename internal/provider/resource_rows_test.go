@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	helper "github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestResourceRows(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping long test")
+	}
+
+	for _, server := range testServers {
+		t.Run(server.ServerType, func(t *testing.T) {
+			url, _, err := server.URL()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			config := func(rows string) string {
+				return fmt.Sprintf(`
+provider "sql" {
+	url = %q
+}
+
+resource "sql_table" "test" {
+	name        = "tf_rows_test"
+	primary_key = ["code"]
+
+	column {
+		name = "code"
+		type = "varchar(10)"
+	}
+
+	column {
+		name = "amount"
+		type = "decimal(10,2)"
+	}
+}
+
+resource "sql_rows" "test" {
+	table       = sql_table.test.name
+	key_columns = ["code"]
+	rows        = %s
+}
+`, url, rows)
+			}
+
+			helper.UnitTest(t, helper.TestCase{
+				ProtoV6ProviderFactories: protoV6ProviderFactories,
+				Steps: []helper.TestStep{
+					{
+						Config: config(`[
+		{ code = "a", amount = "1.5" },
+		{ code = "b", amount = null },
+	]`),
+						Check: helper.ComposeTestCheckFunc(
+							helper.TestCheckResourceAttr("sql_rows.test", "rows.#", "2"),
+							// compared by the database rather than as 1.50
+							helper.TestCheckResourceAttr("sql_rows.test", "rows.0.amount", "1.5"),
+						),
+					},
+					{
+						Config: config(`[
+		{ code = "a", amount = "2" },
+		{ code = "c", amount = "3" },
+	]`),
+						Check: helper.ComposeTestCheckFunc(
+							helper.TestCheckResourceAttr("sql_rows.test", "rows.#", "2"),
+							helper.TestCheckResourceAttr("sql_rows.test", "rows.1.code", "c"),
+						),
+					},
+				},
+			})
+		})
+	}
+}
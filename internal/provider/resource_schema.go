@@ -0,0 +1,282 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/ialexj/terraform-provider-sql/internal/server"
+)
+
+type resourceSchema struct {
+	db dbConnector
+}
+
+var (
+	_ server.Resource         = (*resourceSchema)(nil)
+	_ server.ResourceUpdater  = (*resourceSchema)(nil)
+	_ server.ResourceReplacer = (*resourceSchema)(nil)
+	_ server.ResourceImporter = (*resourceSchema)(nil)
+)
+
+func newResourceSchema(db dbConnector) (*resourceSchema, error) {
+	return &resourceSchema{
+		db: db,
+	}, nil
+}
+
+func (r *resourceSchema) Schema(ctx context.Context) *tfprotov6.Schema {
+	return &tfprotov6.Schema{
+		Block: &tfprotov6.SchemaBlock{
+			Description: "Manages a schema, which is a database for MySQL. Schemas can be imported by name, and must " +
+				"be empty to be destroyed.",
+			DescriptionKind: tfprotov6.StringKindMarkdown,
+			Attributes: []*tfprotov6.SchemaAttribute{
+				urlAttribute(),
+				initStatementsAttribute("SQL statements to run when connecting for this schema, after those of the provider."),
+				{
+					Name:            "name",
+					Required:        true,
+					Description:     "The name of the schema. Changing it replaces the schema.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.String,
+				},
+				{
+					Name:     "owner",
+					Optional: true,
+					Computed: true,
+					Description: "The role or user owning the schema, defaults to the connecting one. Not supported " +
+						"by MySQL.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.String,
+				},
+				{
+					Name:            "id",
+					Computed:        true,
+					Description:     "The name of the schema.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.String,
+				},
+			},
+		},
+	}
+}
+
+func (r *resourceSchema) Validate(ctx context.Context, config map[string]tftypes.Value) ([]*tfprotov6.Diagnostic, error) {
+	var diags []*tfprotov6.Diagnostic
+
+	if v := config["name"]; v.IsKnown() {
+		var name string
+		err := v.As(&name)
+		if err != nil {
+			return nil, err
+		}
+		if strings.TrimSpace(name) == "" {
+			diags = append(diags, errorDiag("Name cannot be empty.", tftypes.AttributeName("name")))
+		}
+	}
+
+	return diags, nil
+}
+
+func (r *resourceSchema) PlanCreate(ctx context.Context, proposed map[string]tftypes.Value, config map[string]tftypes.Value) (map[string]tftypes.Value, []*tfprotov6.Diagnostic, error) {
+	planned := r.plan(proposed, config)
+
+	// the owner is only known once created
+	if config["owner"].IsNull() {
+		planned["owner"] = tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+	}
+
+	return planned, nil, nil
+}
+
+func (r *resourceSchema) PlanUpdate(ctx context.Context, proposed map[string]tftypes.Value, config map[string]tftypes.Value, prior map[string]tftypes.Value) (map[string]tftypes.Value, []*tfprotov6.Diagnostic, error) {
+	return r.plan(proposed, config), nil, nil
+}
+
+func (r *resourceSchema) plan(proposed map[string]tftypes.Value, config map[string]tftypes.Value) map[string]tftypes.Value {
+	planned := map[string]tftypes.Value{}
+	for k, v := range proposed {
+		planned[k] = v
+	}
+
+	planned["id"] = config["name"]
+
+	return planned
+}
+
+func (r *resourceSchema) RequiresReplace(ctx context.Context, planned map[string]tftypes.Value, prior map[string]tftypes.Value) ([]*tftypes.AttributePath, error) {
+	if !planned["name"].Equal(prior["name"]) {
+		return []*tftypes.AttributePath{tftypes.NewAttributePath().WithAttributeName("name")}, nil
+	}
+	return nil, nil
+}
+
+func (r *resourceSchema) Import(ctx context.Context, id string) (map[string]tftypes.Value, []*tfprotov6.Diagnostic, error) {
+	return map[string]tftypes.Value{
+		"name": tftypes.NewValue(tftypes.String, id),
+		"id":   tftypes.NewValue(tftypes.String, id),
+	}, nil, nil
+}
+
+func (r *resourceSchema) Read(ctx context.Context, current map[string]tftypes.Value) (map[string]tftypes.Value, []*tfprotov6.Diagnostic, error) {
+	ds, db, err := r.db.GetQueryer(ctx, current)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return r.read(ctx, ds, db, current)
+}
+
+func (r *resourceSchema) read(ctx context.Context, ds dataSource, db dbQueryer, current map[string]tftypes.Value) (map[string]tftypes.Value, []*tfprotov6.Diagnostic, error) {
+	d, err := schemaDialectFor(ds)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var name string
+	err = current["name"].As(&name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	actual, err := d.ReadSchema(ctx, db, name)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to read schema %q: %w", name, err)
+	}
+	if actual == nil {
+		// the schema was dropped outside of Terraform
+		return nil, nil, nil
+	}
+
+	state := map[string]tftypes.Value{}
+	for k, v := range current {
+		state[k] = v
+	}
+	state["owner"] = tftypes.NewValue(tftypes.String, actual.Owner)
+
+	return state, nil, nil
+}
+
+func (r *resourceSchema) Create(ctx context.Context, planned map[string]tftypes.Value, config map[string]tftypes.Value, prior map[string]tftypes.Value) (map[string]tftypes.Value, []*tfprotov6.Diagnostic, error) {
+	ds, db, err := r.db.GetQueryExecer(ctx, config)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	d, err := schemaDialectFor(ds)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	created, err := schemaFromValues(planned)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stmts, err := d.CreateSchema(created)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = execStatements(ctx, db, stmts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	state, diags, err := r.read(ctx, ds, db, planned)
+	if err != nil {
+		return nil, nil, err
+	}
+	if state == nil {
+		return nil, nil, fmt.Errorf("schema %q was not found after creating it", created.Name)
+	}
+	return state, diags, nil
+}
+
+func (r *resourceSchema) Update(ctx context.Context, planned map[string]tftypes.Value, config map[string]tftypes.Value, prior map[string]tftypes.Value) (map[string]tftypes.Value, []*tfprotov6.Diagnostic, error) {
+	ds, db, err := r.db.GetExecer(ctx, config)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	d, err := schemaDialectFor(ds)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	priorSchema, err := schemaFromValues(prior)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	plannedSchema, err := schemaFromValues(planned)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stmts, err := d.AlterSchema(priorSchema, plannedSchema)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = execStatements(ctx, db, stmts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return planned, nil, nil
+}
+
+func (r *resourceSchema) Destroy(ctx context.Context, prior map[string]tftypes.Value) ([]*tfprotov6.Diagnostic, error) {
+	ds, db, err := r.db.GetExecer(ctx, prior)
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := schemaDialectFor(ds)
+	if err != nil {
+		return nil, err
+	}
+
+	var name string
+	err = prior["name"].As(&name)
+	if err != nil {
+		return nil, err
+	}
+
+	err = execStatements(ctx, db, []string{d.DropSchema(name)})
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func schemaDialectFor(ds dataSource) (schemaDialect, error) {
+	d, ok := ds.dialect().(schemaDialect)
+	if !ok {
+		return nil, fmt.Errorf("schemas are not supported for the %s driver", ds.driver)
+	}
+	return d, nil
+}
+
+// schemaFromValues reads the schema from a plan or state, the owner is nil if
+// not yet known.
+func schemaFromValues(values map[string]tftypes.Value) (dbSchema, error) {
+	var s dbSchema
+
+	err := values["name"].As(&s.Name)
+	if err != nil {
+		return s, err
+	}
+	s.Owner, err = knownStringFromValue(values["owner"])
+	if err != nil {
+		return s, err
+	}
+
+	return s, nil
+}
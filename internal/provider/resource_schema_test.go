@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	helper "github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestResourceSchema(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping long test")
+	}
+
+	for _, server := range testServers {
+		t.Run(server.ServerType, func(t *testing.T) {
+			url, _, err := server.URL()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			helper.UnitTest(t, helper.TestCase{
+				ProtoV6ProviderFactories: protoV6ProviderFactories,
+				Steps: []helper.TestStep{
+					{
+						Config: fmt.Sprintf(`
+provider "sql" {
+	url = %q
+}
+
+resource "sql_schema" "test" {
+	name = "tf_schema_test"
+}
+`, url),
+						Check: helper.ComposeTestCheckFunc(
+							helper.TestCheckResourceAttr("sql_schema.test", "id", "tf_schema_test"),
+						),
+					},
+					{
+						ResourceName:      "sql_schema.test",
+						ImportState:       true,
+						ImportStateId:     "tf_schema_test",
+						ImportStateVerify: true,
+					},
+				},
+			})
+		})
+	}
+}
@@ -0,0 +1,797 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/ialexj/terraform-provider-sql/internal/server"
+)
+
+var (
+	tableColumnType = tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"name":     tftypes.String,
+			"type":     tftypes.String,
+			"nullable": tftypes.Bool,
+			"default":  tftypes.String,
+		},
+	}
+	tableIndexType = tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"name":    tftypes.String,
+			"columns": tftypes.List{ElementType: tftypes.String},
+			"unique":  tftypes.Bool,
+		},
+	}
+)
+
+type resourceTable struct {
+	db dbConnector
+}
+
+var (
+	_ server.Resource         = (*resourceTable)(nil)
+	_ server.ResourceUpdater  = (*resourceTable)(nil)
+	_ server.ResourceReplacer = (*resourceTable)(nil)
+)
+
+func newResourceTable(db dbConnector) (*resourceTable, error) {
+	return &resourceTable{
+		db: db,
+	}, nil
+}
+
+func (r *resourceTable) Schema(ctx context.Context) *tfprotov6.Schema {
+	return &tfprotov6.Schema{
+		Block: &tfprotov6.SchemaBlock{
+			Description: "Manages a table declaratively. Columns and indexes are changed in place with " +
+				"`ALTER TABLE`, while changing the name, schema or primary key replaces the table, dropping its data. " +
+				"Plans that drop a column or change its type have a warning, as they can lose data.",
+			DescriptionKind: tfprotov6.StringKindMarkdown,
+			Attributes: []*tfprotov6.SchemaAttribute{
+				urlAttribute(),
+				initStatementsAttribute("SQL statements to run when connecting for this table, after those of the provider."),
+				{
+					Name:            "schema",
+					Optional:        true,
+					Description:     "The schema of the table, or the database for MySQL. Defaults to the current schema of the connection.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.String,
+				},
+				{
+					Name:            "name",
+					Required:        true,
+					Description:     "The name of the table.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.String,
+				},
+				{
+					Name:            "primary_key",
+					Optional:        true,
+					Description:     "The columns of the primary key, in order.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.List{ElementType: tftypes.String},
+				},
+				{
+					Name:            "id",
+					Computed:        true,
+					Description:     "The name of the table, qualified by its schema if set.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.String,
+				},
+			},
+			BlockTypes: []*tfprotov6.SchemaNestedBlock{
+				{
+					TypeName: "column",
+					Nesting:  tfprotov6.SchemaNestedBlockNestingModeList,
+					MinItems: 1,
+					Block: &tfprotov6.SchemaBlock{
+						Attributes: []*tfprotov6.SchemaAttribute{
+							{
+								Name:            "name",
+								Required:        true,
+								Description:     "The name of the column.",
+								DescriptionKind: tfprotov6.StringKindMarkdown,
+								Type:            tftypes.String,
+							},
+							{
+								Name:     "type",
+								Required: true,
+								Description: "The data type of the column as written in `CREATE TABLE`, ie. `varchar(255)`. Aliases " +
+									"are compared to the type reported by the database, so `int` matches `integer`. Auto " +
+									"incrementing is part of the type, ie. `serial`, `int auto_increment` or `int identity(1,1)`.",
+								DescriptionKind: tfprotov6.StringKindMarkdown,
+								Type:            tftypes.String,
+							},
+							{
+								Name:            "nullable",
+								Optional:        true,
+								Computed:        true,
+								Description:     "Whether the column allows nulls, defaults to `true` unless it is part of the primary key.",
+								DescriptionKind: tfprotov6.StringKindMarkdown,
+								Type:            tftypes.Bool,
+							},
+							{
+								Name:     "default",
+								Optional: true,
+								Description: "The SQL expression of the default value, ie. `'none'` or `now()`. Databases store " +
+									"defaults in their own form, so only adding or removing a default is detected as drift.",
+								DescriptionKind: tfprotov6.StringKindMarkdown,
+								Type:            tftypes.String,
+							},
+						},
+					},
+				},
+				{
+					TypeName: "index",
+					Nesting:  tfprotov6.SchemaNestedBlockNestingModeList,
+					Block: &tfprotov6.SchemaBlock{
+						Attributes: []*tfprotov6.SchemaAttribute{
+							{
+								Name:            "name",
+								Required:        true,
+								Description:     "The name of the index.",
+								DescriptionKind: tfprotov6.StringKindMarkdown,
+								Type:            tftypes.String,
+							},
+							{
+								Name:            "columns",
+								Required:        true,
+								Description:     "The indexed columns, in order.",
+								DescriptionKind: tfprotov6.StringKindMarkdown,
+								Type:            tftypes.List{ElementType: tftypes.String},
+							},
+							{
+								Name:            "unique",
+								Optional:        true,
+								Computed:        true,
+								Description:     "Whether the index is unique, defaults to `false`.",
+								DescriptionKind: tfprotov6.StringKindMarkdown,
+								Type:            tftypes.Bool,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *resourceTable) Validate(ctx context.Context, config map[string]tftypes.Value) ([]*tfprotov6.Diagnostic, error) {
+	var diags []*tfprotov6.Diagnostic
+
+	columns := map[string]bool{}
+	if v := config["column"]; v.IsFullyKnown() && !v.IsNull() {
+		var values []tftypes.Value
+		err := v.As(&values)
+		if err != nil {
+			return nil, err
+		}
+
+		for i, cv := range values {
+			var c map[string]tftypes.Value
+			err = cv.As(&c)
+			if err != nil {
+				return nil, err
+			}
+
+			var name string
+			err = c["name"].As(&name)
+			if err != nil {
+				return nil, err
+			}
+
+			path := []tftypes.AttributePathStep{tftypes.AttributeName("column"), tftypes.ElementKeyInt(i), tftypes.AttributeName("name")}
+			switch {
+			case strings.TrimSpace(name) == "":
+				diags = append(diags, errorDiag("Column name cannot be empty.", path...))
+			case columns[name]:
+				diags = append(diags, errorDiag(fmt.Sprintf("Duplicate column name %q.", name), path...))
+			}
+			columns[name] = true
+		}
+	} else {
+		// the columns referenced by the primary key and indexes can't be
+		// checked yet
+		columns = nil
+	}
+
+	var primaryKey []string
+	if v := config["primary_key"]; v.IsFullyKnown() {
+		var err error
+		primaryKey, err = stringsFromValue(v)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for i, name := range primaryKey {
+		if columns != nil && !columns[name] {
+			diags = append(diags, errorDiag(fmt.Sprintf("Primary key column %q is not defined.", name),
+				tftypes.AttributeName("primary_key"), tftypes.ElementKeyInt(i)))
+		}
+	}
+
+	if v := config["index"]; v.IsFullyKnown() && !v.IsNull() {
+		var values []tftypes.Value
+		err := v.As(&values)
+		if err != nil {
+			return nil, err
+		}
+
+		indexes := map[string]bool{}
+		for i, iv := range values {
+			var index map[string]tftypes.Value
+			err = iv.As(&index)
+			if err != nil {
+				return nil, err
+			}
+
+			var name string
+			err = index["name"].As(&name)
+			if err != nil {
+				return nil, err
+			}
+
+			path := []tftypes.AttributePathStep{tftypes.AttributeName("index"), tftypes.ElementKeyInt(i)}
+			switch {
+			case strings.TrimSpace(name) == "":
+				diags = append(diags, errorDiag("Index name cannot be empty.", append(path, tftypes.AttributeName("name"))...))
+			case indexes[name]:
+				diags = append(diags, errorDiag(fmt.Sprintf("Duplicate index name %q.", name), append(path, tftypes.AttributeName("name"))...))
+			}
+			indexes[name] = true
+
+			indexColumns, err := stringsFromValue(index["columns"])
+			if err != nil {
+				return nil, err
+			}
+			if len(indexColumns) == 0 {
+				diags = append(diags, errorDiag("At least one index column is required.", append(path, tftypes.AttributeName("columns"))...))
+			}
+			for j, column := range indexColumns {
+				if columns != nil && !columns[column] {
+					diags = append(diags, errorDiag(fmt.Sprintf("Index column %q is not defined.", column),
+						append(path, tftypes.AttributeName("columns"), tftypes.ElementKeyInt(j))...))
+				}
+			}
+		}
+	}
+
+	return diags, nil
+}
+
+func (r *resourceTable) PlanCreate(ctx context.Context, proposed map[string]tftypes.Value, config map[string]tftypes.Value) (map[string]tftypes.Value, []*tfprotov6.Diagnostic, error) {
+	return r.plan(ctx, proposed, config)
+}
+
+func (r *resourceTable) PlanUpdate(ctx context.Context, proposed map[string]tftypes.Value, config map[string]tftypes.Value, prior map[string]tftypes.Value) (map[string]tftypes.Value, []*tfprotov6.Diagnostic, error) {
+	planned, diags, err := r.plan(ctx, proposed, config)
+	if err != nil || !planned["column"].IsFullyKnown() || !planned["url"].IsKnown() {
+		return planned, diags, err
+	}
+
+	// changes to the columns are applied in place, warn about those that lose
+	// data rather than replacing the whole table
+	ds, err := r.db.GetDataSource(planned["url"])
+	if err != nil {
+		return planned, diags, nil
+	}
+	d, ok := ds.dialect().(tableDialect)
+	if !ok {
+		return planned, diags, nil
+	}
+
+	priorColumns, err := columnsFromValue(prior["column"])
+	if err != nil {
+		return nil, nil, err
+	}
+	plannedColumns, err := columnsFromValue(planned["column"])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return planned, append(diags, columnChangeDiags(d, priorColumns, plannedColumns)...), nil
+}
+
+func (r *resourceTable) RequiresReplace(ctx context.Context, planned map[string]tftypes.Value, prior map[string]tftypes.Value) ([]*tftypes.AttributePath, error) {
+	var paths []*tftypes.AttributePath
+	for _, name := range []string{"schema", "name", "primary_key"} {
+		if !planned[name].Equal(prior[name]) {
+			paths = append(paths, tftypes.NewAttributePath().WithAttributeName(name))
+		}
+	}
+	return paths, nil
+}
+
+// plan fills in the computed defaults of the columns and indexes from the
+// config, rather than keeping those of the prior state.
+func (r *resourceTable) plan(ctx context.Context, proposed map[string]tftypes.Value, config map[string]tftypes.Value) (map[string]tftypes.Value, []*tfprotov6.Diagnostic, error) {
+	planned := map[string]tftypes.Value{}
+	for k, v := range proposed {
+		planned[k] = v
+	}
+
+	switch schema, name := config["schema"], config["name"]; {
+	case !schema.IsKnown() || !name.IsKnown():
+		planned["id"] = tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+	default:
+		var s, n string
+		if err := schema.As(&s); err != nil {
+			return nil, nil, err
+		}
+		if err := name.As(&n); err != nil {
+			return nil, nil, err
+		}
+		planned["id"] = tftypes.NewValue(tftypes.String, tableID(s, n))
+	}
+
+	if columns := config["column"]; columns.IsKnown() && !columns.IsNull() {
+		var values []tftypes.Value
+		err := columns.As(&values)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		primaryKey := map[string]bool{}
+		pkKnown := config["primary_key"].IsFullyKnown()
+		if pkKnown {
+			names, err := stringsFromValue(config["primary_key"])
+			if err != nil {
+				return nil, nil, err
+			}
+			for _, name := range names {
+				primaryKey[name] = true
+			}
+		}
+
+		for i, cv := range values {
+			var c map[string]tftypes.Value
+			err = cv.As(&c)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			if c["nullable"].IsNull() {
+				var name string
+				switch {
+				case !pkKnown || !c["name"].IsKnown():
+					c["nullable"] = tftypes.NewValue(tftypes.Bool, tftypes.UnknownValue)
+				case c["name"].As(&name) != nil:
+					return nil, nil, fmt.Errorf("unable to read column name")
+				default:
+					c["nullable"] = tftypes.NewValue(tftypes.Bool, !primaryKey[name])
+				}
+			}
+			values[i] = tftypes.NewValue(tableColumnType, c)
+		}
+		planned["column"] = tftypes.NewValue(tftypes.List{ElementType: tableColumnType}, values)
+	}
+
+	if indexes := config["index"]; indexes.IsKnown() && !indexes.IsNull() {
+		var values []tftypes.Value
+		err := indexes.As(&values)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for i, iv := range values {
+			var index map[string]tftypes.Value
+			err = iv.As(&index)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			if index["unique"].IsNull() {
+				index["unique"] = tftypes.NewValue(tftypes.Bool, false)
+			}
+			values[i] = tftypes.NewValue(tableIndexType, index)
+		}
+		planned["index"] = tftypes.NewValue(tftypes.List{ElementType: tableIndexType}, values)
+	}
+
+	return planned, nil, nil
+}
+
+func (r *resourceTable) Read(ctx context.Context, current map[string]tftypes.Value) (map[string]tftypes.Value, []*tfprotov6.Diagnostic, error) {
+	ds, db, err := r.db.GetQueryer(ctx, current)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	d, err := tableDialectFor(ds)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	prior, err := tableFromValues(current)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	schema := prior.Schema
+	if schema == "" {
+		schema, err = currentSchema(ctx, d, db)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	actual, err := readTable(ctx, d, db, schema, prior.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+	if actual == nil {
+		// the table was dropped outside of Terraform
+		return nil, nil, nil
+	}
+
+	state := map[string]tftypes.Value{}
+	for k, v := range current {
+		state[k] = v
+	}
+	state["column"] = tableColumnsValue(reconcileColumns(d, prior.Columns, actual.Columns))
+	state["index"] = tableIndexesValue(reconcileIndexes(prior.Indexes, actual.Indexes))
+	if len(actual.PrimaryKey) > 0 || len(prior.PrimaryKey) > 0 {
+		state["primary_key"] = stringsValue(actual.PrimaryKey)
+	}
+
+	return state, nil, nil
+}
+
+func (r *resourceTable) Create(ctx context.Context, planned map[string]tftypes.Value, config map[string]tftypes.Value, prior map[string]tftypes.Value) (map[string]tftypes.Value, []*tfprotov6.Diagnostic, error) {
+	ds, db, err := r.db.GetExecer(ctx, config)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	d, err := tableDialectFor(ds)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	t, err := tableFromValues(planned)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = execStatements(ctx, db, createTableStatements(d, t))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return planned, nil, nil
+}
+
+func (r *resourceTable) Update(ctx context.Context, planned map[string]tftypes.Value, config map[string]tftypes.Value, prior map[string]tftypes.Value) (map[string]tftypes.Value, []*tfprotov6.Diagnostic, error) {
+	ds, db, err := r.db.GetExecer(ctx, config)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	d, err := tableDialectFor(ds)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	priorTable, err := tableFromValues(prior)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	plannedTable, err := tableFromValues(planned)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = execStatements(ctx, db, alterTableStatements(d, priorTable, plannedTable))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return planned, nil, nil
+}
+
+func (r *resourceTable) Destroy(ctx context.Context, prior map[string]tftypes.Value) ([]*tfprotov6.Diagnostic, error) {
+	ds, db, err := r.db.GetExecer(ctx, prior)
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := tableDialectFor(ds)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := tableFromValues(prior)
+	if err != nil {
+		return nil, err
+	}
+
+	err = execStatements(ctx, db, []string{dropTableStatement(d, t)})
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func tableDialectFor(ds dataSource) (tableDialect, error) {
+	d, ok := ds.dialect().(tableDialect)
+	if !ok {
+		return nil, fmt.Errorf("tables are not supported for the %s driver", ds.driver)
+	}
+	return d, nil
+}
+
+// tableID returns the name of the table, qualified by the schema if set.
+func tableID(schema, name string) string {
+	if schema == "" {
+		return name
+	}
+	return schema + "." + name
+}
+
+// execStatements runs each of the statements in order, stopping at the first
+// error.
+func execStatements(ctx context.Context, db dbExecer, stmts []string) error {
+	for _, stmt := range stmts {
+		_, err := db.ExecContext(ctx, stmt)
+		if err != nil {
+			return fmt.Errorf("unable to execute %q: %w", stmt, err)
+		}
+	}
+	return nil
+}
+
+// columnChangeDiags warns about the planned column changes that can lose data,
+// which are dropping a column or changing its type.
+func columnChangeDiags(d tableDialect, prior, planned []tableColumn) []*tfprotov6.Diagnostic {
+	byName := map[string]tableColumn{}
+	for _, c := range prior {
+		byName[c.Name] = c
+	}
+
+	var diags []*tfprotov6.Diagnostic
+	kept := map[string]bool{}
+	for i, c := range planned {
+		kept[c.Name] = true
+		p, ok := byName[c.Name]
+		if !ok || sameColumnType(d, p.Type, c.Type) {
+			continue
+		}
+		diags = append(diags, &tfprotov6.Diagnostic{
+			Severity: tfprotov6.DiagnosticSeverityWarning,
+			Attribute: tftypes.NewAttributePathWithSteps([]tftypes.AttributePathStep{
+				tftypes.AttributeName("column"), tftypes.ElementKeyInt(i), tftypes.AttributeName("type"),
+			}),
+			Summary: fmt.Sprintf("The type of column %q will be changed from %s to %s.", c.Name, p.Type, c.Type),
+			Detail: "The column is altered in place and its existing values are converted, which fails or loses " +
+				"data if they do not fit the new type.",
+		})
+	}
+
+	for _, p := range prior {
+		if kept[p.Name] {
+			continue
+		}
+		diags = append(diags, &tfprotov6.Diagnostic{
+			Severity: tfprotov6.DiagnosticSeverityWarning,
+			Attribute: tftypes.NewAttributePathWithSteps([]tftypes.AttributePathStep{
+				tftypes.AttributeName("column"),
+			}),
+			Summary: fmt.Sprintf("Column %q will be dropped.", p.Name),
+			Detail:  "The column and all of its values are removed from the table. Renaming a column also drops it.",
+		})
+	}
+
+	return diags
+}
+
+// reconcileColumns orders the actual columns as they were in the prior state,
+// followed by any new ones, keeping the prior spelling of equivalent types
+// and defaults.
+func reconcileColumns(d tableDialect, prior, actual []tableColumn) []tableColumn {
+	byName := map[string]tableColumn{}
+	for _, c := range actual {
+		byName[c.Name] = c
+	}
+
+	columns := make([]tableColumn, 0, len(actual))
+	seen := map[string]bool{}
+	for _, p := range prior {
+		c, ok := byName[p.Name]
+		if !ok {
+			continue
+		}
+		if sameColumnType(d, p.Type, c.Type) {
+			c.Type = p.Type
+		}
+		if p.Default != nil && c.Default != nil && sameDefault(d, *p.Default, *c.Default) {
+			c.Default = p.Default
+		}
+		columns = append(columns, c)
+		seen[c.Name] = true
+	}
+
+	for _, c := range actual {
+		if !seen[c.Name] {
+			columns = append(columns, c)
+		}
+	}
+
+	return columns
+}
+
+// reconcileIndexes orders the actual indexes as they were in the prior state,
+// followed by any new ones.
+func reconcileIndexes(prior, actual []tableIndex) []tableIndex {
+	byName := map[string]tableIndex{}
+	for _, index := range actual {
+		byName[index.Name] = index
+	}
+
+	indexes := make([]tableIndex, 0, len(actual))
+	seen := map[string]bool{}
+	for _, p := range prior {
+		if index, ok := byName[p.Name]; ok {
+			indexes = append(indexes, index)
+			seen[index.Name] = true
+		}
+	}
+
+	for _, index := range actual {
+		if !seen[index.Name] {
+			indexes = append(indexes, index)
+		}
+	}
+
+	return indexes
+}
+
+// tableFromValues reads the table from a fully known plan or state.
+func tableFromValues(values map[string]tftypes.Value) (table, error) {
+	var t table
+
+	err := values["schema"].As(&t.Schema)
+	if err != nil {
+		return t, err
+	}
+	err = values["name"].As(&t.Name)
+	if err != nil {
+		return t, err
+	}
+	t.PrimaryKey, err = stringsFromValue(values["primary_key"])
+	if err != nil {
+		return t, err
+	}
+
+	t.Columns, err = columnsFromValue(values["column"])
+	if err != nil {
+		return t, err
+	}
+
+	var indexes []tftypes.Value
+	err = values["index"].As(&indexes)
+	if err != nil {
+		return t, err
+	}
+	for _, iv := range indexes {
+		var ivs map[string]tftypes.Value
+		err = iv.As(&ivs)
+		if err != nil {
+			return t, err
+		}
+
+		var index tableIndex
+		err = ivs["name"].As(&index.Name)
+		if err != nil {
+			return t, err
+		}
+		err = ivs["unique"].As(&index.Unique)
+		if err != nil {
+			return t, err
+		}
+		index.Columns, err = stringsFromValue(ivs["columns"])
+		if err != nil {
+			return t, err
+		}
+		t.Indexes = append(t.Indexes, index)
+	}
+
+	return t, nil
+}
+
+// columnsFromValue reads a fully known list of column blocks.
+func columnsFromValue(v tftypes.Value) ([]tableColumn, error) {
+	var values []tftypes.Value
+	err := v.As(&values)
+	if err != nil {
+		return nil, err
+	}
+
+	var columns []tableColumn
+	for _, cv := range values {
+		var cvs map[string]tftypes.Value
+		err = cv.As(&cvs)
+		if err != nil {
+			return nil, err
+		}
+
+		var c tableColumn
+		for name, target := range map[string]interface{}{
+			"name":     &c.Name,
+			"type":     &c.Type,
+			"nullable": &c.Nullable,
+			"default":  &c.Default,
+		} {
+			err = cvs[name].As(target)
+			if err != nil {
+				return nil, fmt.Errorf("unable to read column %s: %w", name, err)
+			}
+		}
+		columns = append(columns, c)
+	}
+	return columns, nil
+}
+
+func tableColumnsValue(columns []tableColumn) tftypes.Value {
+	values := make([]tftypes.Value, 0, len(columns))
+	for _, c := range columns {
+		values = append(values, tftypes.NewValue(tableColumnType, map[string]tftypes.Value{
+			"name":     tftypes.NewValue(tftypes.String, c.Name),
+			"type":     tftypes.NewValue(tftypes.String, c.Type),
+			"nullable": tftypes.NewValue(tftypes.Bool, c.Nullable),
+			"default":  tftypes.NewValue(tftypes.String, c.Default),
+		}))
+	}
+	return tftypes.NewValue(tftypes.List{ElementType: tableColumnType}, values)
+}
+
+func tableIndexesValue(indexes []tableIndex) tftypes.Value {
+	values := make([]tftypes.Value, 0, len(indexes))
+	for _, index := range indexes {
+		values = append(values, tftypes.NewValue(tableIndexType, map[string]tftypes.Value{
+			"name":    tftypes.NewValue(tftypes.String, index.Name),
+			"columns": stringsValue(index.Columns),
+			"unique":  tftypes.NewValue(tftypes.Bool, index.Unique),
+		}))
+	}
+	return tftypes.NewValue(tftypes.List{ElementType: tableIndexType}, values)
+}
+
+// stringsFromValue reads a list of strings, which is empty if null.
+func stringsFromValue(v tftypes.Value) ([]string, error) {
+	if v.IsNull() {
+		return nil, nil
+	}
+
+	var values []tftypes.Value
+	err := v.As(&values)
+	if err != nil {
+		return nil, err
+	}
+
+	strs := make([]string, 0, len(values))
+	for _, sv := range values {
+		var s string
+		err = sv.As(&s)
+		if err != nil {
+			return nil, err
+		}
+		strs = append(strs, s)
+	}
+
+	return strs, nil
+}
+
+func stringsValue(strs []string) tftypes.Value {
+	values := make([]tftypes.Value, 0, len(strs))
+	for _, s := range strs {
+		values = append(values, tftypes.NewValue(tftypes.String, s))
+	}
+	return tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, values)
+}
@@ -0,0 +1,184 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	helper "github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestResourceTable_plan(t *testing.T) {
+	r := &resourceTable{}
+
+	column := func(name string, nullable interface{}) tftypes.Value {
+		return tftypes.NewValue(tableColumnType, map[string]tftypes.Value{
+			"name":     tftypes.NewValue(tftypes.String, name),
+			"type":     tftypes.NewValue(tftypes.String, "int"),
+			"nullable": tftypes.NewValue(tftypes.Bool, nullable),
+			"default":  tftypes.NewValue(tftypes.String, nil),
+		})
+	}
+
+	config := map[string]tftypes.Value{
+		"url":             tftypes.NewValue(tftypes.String, nil),
+		"init_statements": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, nil),
+		"schema":          tftypes.NewValue(tftypes.String, "app"),
+		"name":            tftypes.NewValue(tftypes.String, "items"),
+		"primary_key":     stringsValue([]string{"id"}),
+		"id":              tftypes.NewValue(tftypes.String, nil),
+		"column": tftypes.NewValue(tftypes.List{ElementType: tableColumnType}, []tftypes.Value{
+			column("id", nil),
+			column("a", nil),
+			column("b", false),
+		}),
+		"index": tftypes.NewValue(tftypes.List{ElementType: tableIndexType}, []tftypes.Value{
+			tftypes.NewValue(tableIndexType, map[string]tftypes.Value{
+				"name":    tftypes.NewValue(tftypes.String, "items_a"),
+				"columns": stringsValue([]string{"a"}),
+				"unique":  tftypes.NewValue(tftypes.Bool, nil),
+			}),
+		}),
+	}
+
+	planned, diags, err := r.PlanCreate(context.Background(), config, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(diags) > 0 {
+		t.Fatalf("unexpected diags: %v", diags)
+	}
+
+	tbl, err := tableFromValues(planned)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := table{
+		Schema: "app",
+		Name:   "items",
+		Columns: []tableColumn{
+			{Name: "id", Type: "int", Nullable: false},
+			{Name: "a", Type: "int", Nullable: true},
+			{Name: "b", Type: "int", Nullable: false},
+		},
+		PrimaryKey: []string{"id"},
+		Indexes: []tableIndex{
+			{Name: "items_a", Columns: []string{"a"}, Unique: false},
+		},
+	}
+	if diff := cmp.Diff(expected, tbl); diff != "" {
+		t.Fatalf("unexpected table (-expected +actual):\n%s", diff)
+	}
+
+	if !planned["id"].Equal(tftypes.NewValue(tftypes.String, "app.items")) {
+		t.Fatalf("unexpected id: %s", planned["id"])
+	}
+
+	// the state must be representable as the schema type
+	tftypes.NewValue(tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"url":             tftypes.String,
+		"init_statements": tftypes.List{ElementType: tftypes.String},
+		"schema":          tftypes.String,
+		"name":            tftypes.String,
+		"primary_key":     tftypes.List{ElementType: tftypes.String},
+		"id":              tftypes.String,
+		"column":          tftypes.List{ElementType: tableColumnType},
+		"index":           tftypes.List{ElementType: tableIndexType},
+	}}, planned)
+
+	prior := planned
+	planned = map[string]tftypes.Value{}
+	for k, v := range prior {
+		planned[k] = v
+	}
+	planned["primary_key"] = stringsValue([]string{"id", "a"})
+
+	paths, err := r.RequiresReplace(context.Background(), planned, prior)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) != 1 || !paths[0].Equal(tftypes.NewAttributePath().WithAttributeName("primary_key")) {
+		t.Fatalf("expected primary_key to require replacement, got %v", paths)
+	}
+}
+
+func TestResourceTable(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping long test")
+	}
+
+	for _, server := range testServers {
+		t.Run(server.ServerType, func(t *testing.T) {
+			url, _, err := server.URL()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			config := func(columns string) string {
+				return fmt.Sprintf(`
+provider "sql" {
+	url = %q
+}
+
+resource "sql_table" "test" {
+	name        = "tf_table_test"
+	primary_key = ["id"]
+
+	column {
+		name = "id"
+		type = "int"
+	}
+
+	%s
+
+	index {
+		name    = "tf_table_test_name"
+		columns = ["name"]
+	}
+}
+`, url, columns)
+			}
+
+			helper.UnitTest(t, helper.TestCase{
+				ProtoV6ProviderFactories: protoV6ProviderFactories,
+				Steps: []helper.TestStep{
+					{
+						Config: config(`
+	column {
+		name = "name"
+		type = "varchar(10)"
+	}`),
+						Check: helper.ComposeTestCheckFunc(
+							helper.TestCheckResourceAttr("sql_table.test", "id", "tf_table_test"),
+							helper.TestCheckResourceAttr("sql_table.test", "column.0.nullable", "false"),
+							helper.TestCheckResourceAttr("sql_table.test", "column.1.nullable", "true"),
+							helper.TestCheckResourceAttr("sql_table.test", "index.0.unique", "false"),
+						),
+					},
+					{
+						Config: config(`
+	column {
+		name     = "name"
+		type     = "varchar(20)"
+		nullable = false
+		default  = "'none'"
+	}
+
+	column {
+		name = "created"
+		type = "date"
+	}`),
+						Check: helper.ComposeTestCheckFunc(
+							helper.TestCheckResourceAttr("sql_table.test", "column.#", "3"),
+							helper.TestCheckResourceAttr("sql_table.test", "column.1.type", "varchar(20)"),
+							helper.TestCheckResourceAttr("sql_table.test", "column.1.nullable", "false"),
+						),
+					},
+				},
+			})
+		})
+	}
+}
@@ -0,0 +1,298 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/ialexj/terraform-provider-sql/internal/server"
+)
+
+type resourceView struct {
+	db dbConnector
+}
+
+var (
+	_ server.Resource         = (*resourceView)(nil)
+	_ server.ResourceUpdater  = (*resourceView)(nil)
+	_ server.ResourceReplacer = (*resourceView)(nil)
+)
+
+func newResourceView(db dbConnector) (*resourceView, error) {
+	return &resourceView{
+		db: db,
+	}, nil
+}
+
+func (r *resourceView) Schema(ctx context.Context) *tfprotov6.Schema {
+	return &tfprotov6.Schema{
+		Block: &tfprotov6.SchemaBlock{
+			Description: "Manages a view. The definition is replaced in place where the database supports it. " +
+				"Changes made outside of Terraform are detected by comparing the definition stored by the database.",
+			DescriptionKind: tfprotov6.StringKindMarkdown,
+			Attributes: []*tfprotov6.SchemaAttribute{
+				urlAttribute(),
+				initStatementsAttribute("SQL statements to run when connecting for this view, after those of the provider."),
+				{
+					Name:            "schema",
+					Optional:        true,
+					Description:     "The schema of the view, defaults to the current schema.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.String,
+				},
+				{
+					Name:            "name",
+					Required:        true,
+					Description:     "The name of the view. Changing it replaces the view.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.String,
+				},
+				{
+					Name:            "definition",
+					Required:        true,
+					Description:     "The query defining the view, such as `SELECT id, name FROM users WHERE active`.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.String,
+				},
+				{
+					Name:     "normalized_definition",
+					Computed: true,
+					Description: "The definition as stored by the database, which may be formatted differently. It is " +
+						"used to detect changes made outside of Terraform.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.String,
+				},
+				{
+					Name:            "id",
+					Computed:        true,
+					Description:     "The schema qualified name of the view.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.String,
+				},
+			},
+		},
+	}
+}
+
+func (r *resourceView) Validate(ctx context.Context, config map[string]tftypes.Value) ([]*tfprotov6.Diagnostic, error) {
+	return nil, nil
+}
+
+func (r *resourceView) PlanCreate(ctx context.Context, proposed map[string]tftypes.Value, config map[string]tftypes.Value) (map[string]tftypes.Value, []*tfprotov6.Diagnostic, error) {
+	planned, err := planDefinition(proposed, config, nil, "definition")
+	return planned, nil, err
+}
+
+func (r *resourceView) PlanUpdate(ctx context.Context, proposed map[string]tftypes.Value, config map[string]tftypes.Value, prior map[string]tftypes.Value) (map[string]tftypes.Value, []*tfprotov6.Diagnostic, error) {
+	planned, err := planDefinition(proposed, config, prior, "definition")
+	return planned, nil, err
+}
+
+func (r *resourceView) RequiresReplace(ctx context.Context, planned map[string]tftypes.Value, prior map[string]tftypes.Value) ([]*tftypes.AttributePath, error) {
+	var paths []*tftypes.AttributePath
+	for _, name := range []string{"schema", "name"} {
+		if !planned[name].Equal(prior[name]) {
+			paths = append(paths, tftypes.NewAttributePath().WithAttributeName(name))
+		}
+	}
+	return paths, nil
+}
+
+func (r *resourceView) Read(ctx context.Context, current map[string]tftypes.Value) (map[string]tftypes.Value, []*tfprotov6.Diagnostic, error) {
+	ds, db, err := r.db.GetQueryer(ctx, current)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	d, err := viewDialectFor(ds)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	v, err := viewFromValues(current)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	actual, err := d.ReadView(ctx, db, v.Schema, v.Name)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to read view %q: %w", v.Name, err)
+	}
+	if actual == nil {
+		// the view was dropped outside of Terraform
+		return nil, nil, nil
+	}
+
+	return readDefinitionState(current, *actual, "definition")
+}
+
+func (r *resourceView) Create(ctx context.Context, planned map[string]tftypes.Value, config map[string]tftypes.Value, prior map[string]tftypes.Value) (map[string]tftypes.Value, []*tfprotov6.Diagnostic, error) {
+	return r.apply(ctx, planned, config)
+}
+
+func (r *resourceView) Update(ctx context.Context, planned map[string]tftypes.Value, config map[string]tftypes.Value, prior map[string]tftypes.Value) (map[string]tftypes.Value, []*tfprotov6.Diagnostic, error) {
+	return r.apply(ctx, planned, config)
+}
+
+// apply creates or replaces the view, and stores the definition read back from
+// the database.
+func (r *resourceView) apply(ctx context.Context, planned map[string]tftypes.Value, config map[string]tftypes.Value) (map[string]tftypes.Value, []*tfprotov6.Diagnostic, error) {
+	ds, db, err := r.db.GetQueryExecer(ctx, config)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	d, err := viewDialectFor(ds)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	v, err := viewFromValues(planned)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = execStatements(ctx, db, d.CreateView(v))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	actual, err := d.ReadView(ctx, db, v.Schema, v.Name)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to read view %q: %w", v.Name, err)
+	}
+	if actual == nil {
+		return nil, nil, fmt.Errorf("view %q was not found after creating it", v.Name)
+	}
+
+	state := map[string]tftypes.Value{}
+	for k, v := range planned {
+		state[k] = v
+	}
+	state["normalized_definition"] = tftypes.NewValue(tftypes.String, *actual)
+
+	return state, nil, nil
+}
+
+func (r *resourceView) Destroy(ctx context.Context, prior map[string]tftypes.Value) ([]*tfprotov6.Diagnostic, error) {
+	ds, db, err := r.db.GetExecer(ctx, prior)
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := viewDialectFor(ds)
+	if err != nil {
+		return nil, err
+	}
+
+	v, err := viewFromValues(prior)
+	if err != nil {
+		return nil, err
+	}
+
+	err = execStatements(ctx, db, []string{d.DropView(v.Schema, v.Name)})
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func viewDialectFor(ds dataSource) (viewDialect, error) {
+	d, ok := ds.dialect().(viewDialect)
+	if !ok {
+		return nil, fmt.Errorf("views are not supported for the %s driver", ds.driver)
+	}
+	return d, nil
+}
+
+func viewFromValues(values map[string]tftypes.Value) (view, error) {
+	var v view
+
+	var schema *string
+	err := values["schema"].As(&schema)
+	if err != nil {
+		return v, err
+	}
+	if schema != nil {
+		v.Schema = *schema
+	}
+	err = values["name"].As(&v.Name)
+	if err != nil {
+		return v, err
+	}
+	err = values["definition"].As(&v.Definition)
+	if err != nil {
+		return v, err
+	}
+
+	return v, nil
+}
+
+// planDefinition plans the id, and the normalized definition as unknown when
+// any of the attributes making up the definition change, as it is only known
+// once the database has stored the new definition.
+func planDefinition(proposed, config, prior map[string]tftypes.Value, attributes ...string) (map[string]tftypes.Value, error) {
+	planned := map[string]tftypes.Value{}
+	for k, v := range proposed {
+		planned[k] = v
+	}
+
+	planned["id"] = tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+	if config["schema"].IsKnown() && config["name"].IsKnown() {
+		var schema *string
+		var name string
+		err := config["schema"].As(&schema)
+		if err != nil {
+			return nil, err
+		}
+		err = config["name"].As(&name)
+		if err != nil {
+			return nil, err
+		}
+		if schema == nil {
+			planned["id"] = tftypes.NewValue(tftypes.String, tableID("", name))
+		} else {
+			planned["id"] = tftypes.NewValue(tftypes.String, tableID(*schema, name))
+		}
+	}
+
+	changed := prior == nil || planned["normalized_definition"].IsNull()
+	for _, name := range attributes {
+		if !changed && !proposed[name].Equal(prior[name]) {
+			changed = true
+		}
+	}
+	if changed {
+		planned["normalized_definition"] = tftypes.NewValue(tftypes.String, tftypes.UnknownValue)
+	}
+
+	return planned, nil
+}
+
+// readDefinitionState refreshes the state with the definition stored by the
+// database. When it differs from the one stored when applying, the definition
+// was changed outside of Terraform, and the source attribute is set to it so
+// that the configured one is planned again.
+func readDefinitionState(current map[string]tftypes.Value, actual string, source string) (map[string]tftypes.Value, []*tfprotov6.Diagnostic, error) {
+	state := map[string]tftypes.Value{}
+	for k, v := range current {
+		state[k] = v
+	}
+
+	var normalized *string
+	err := current["normalized_definition"].As(&normalized)
+	if err != nil {
+		return nil, nil, err
+	}
+	if normalized != nil && *normalized == actual {
+		return state, nil, nil
+	}
+
+	state["normalized_definition"] = tftypes.NewValue(tftypes.String, actual)
+	state[source] = tftypes.NewValue(tftypes.String, actual)
+
+	return state, nil, nil
+}
@@ -0,0 +1,172 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	helper "github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestResourceView(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping long test")
+	}
+
+	for _, server := range testServers {
+		t.Run(server.ServerType, func(t *testing.T) {
+			url, _, err := server.URL()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			config := func(definition string) string {
+				return fmt.Sprintf(`
+provider "sql" {
+	url = %q
+}
+
+resource "sql_view" "test" {
+	name       = "tf_view_test"
+	definition = %q
+}
+`, url, definition)
+			}
+
+			helper.UnitTest(t, helper.TestCase{
+				ProtoV6ProviderFactories: protoV6ProviderFactories,
+				Steps: []helper.TestStep{
+					{
+						Config: config("SELECT 1 AS one"),
+						Check: helper.ComposeTestCheckFunc(
+							helper.TestCheckResourceAttr("sql_view.test", "id", "tf_view_test"),
+							helper.TestCheckResourceAttrSet("sql_view.test", "normalized_definition"),
+						),
+					},
+					{
+						Config: config("SELECT 2 AS one"),
+						Check: helper.ComposeTestCheckFunc(
+							helper.TestCheckResourceAttr("sql_view.test", "definition", "SELECT 2 AS one"),
+						),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestResourceFunction(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping long test")
+	}
+
+	functions := map[string]string{
+		"postgres": `
+	arguments = "a integer, b integer"
+	returns   = "integer"
+	body      = "SELECT a + b"
+`,
+		"mysql": `
+	arguments = "a INT, b INT"
+	returns   = "INT"
+	body      = "DETERMINISTIC RETURN a + b"
+`,
+		"sqlserver": `
+	arguments = "@a int, @b int"
+	returns   = "int"
+	body      = "BEGIN RETURN @a + @b END"
+`,
+	}
+
+	for _, server := range testServers {
+		function, ok := functions[server.ServerType]
+		if !ok {
+			continue
+		}
+
+		t.Run(server.ServerType, func(t *testing.T) {
+			url, _, err := server.URL()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			helper.UnitTest(t, helper.TestCase{
+				ProtoV6ProviderFactories: protoV6ProviderFactories,
+				Steps: []helper.TestStep{
+					{
+						Config: fmt.Sprintf(`
+provider "sql" {
+	url = %q
+}
+
+resource "sql_function" "test" {
+	name      = "tf_function_test"
+%s
+}
+`, url, function),
+						Check: helper.ComposeTestCheckFunc(
+							helper.TestCheckResourceAttr("sql_function.test", "id", "tf_function_test"),
+							helper.TestCheckResourceAttrSet("sql_function.test", "normalized_definition"),
+						),
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestResourceFunction_overloaded(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping long test")
+	}
+
+	for _, server := range testServers {
+		if server.ServerType != "postgres" {
+			continue
+		}
+
+		t.Run(server.ServerType, func(t *testing.T) {
+			url, _, err := server.URL()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			config := fmt.Sprintf(`
+provider "sql" {
+	url = %q
+}
+
+resource "sql_function" "one" {
+	name      = "tf_overloaded"
+	arguments = "a int"
+	returns   = "int"
+	body      = "SELECT a"
+}
+
+resource "sql_function" "two" {
+	name      = "tf_overloaded"
+	arguments = "a int, b int"
+	returns   = "int"
+	body      = "SELECT a + b"
+}
+`, url)
+
+			helper.UnitTest(t, helper.TestCase{
+				ProtoV6ProviderFactories: protoV6ProviderFactories,
+				Steps: []helper.TestStep{
+					{
+						Config: config,
+						Check: helper.ComposeTestCheckFunc(
+							helper.TestMatchResourceAttr("sql_function.one", "normalized_definition", regexp.MustCompile(`SELECT a\$function\$`)),
+							helper.TestMatchResourceAttr("sql_function.two", "normalized_definition", regexp.MustCompile(`SELECT a \+ b\$function\$`)),
+						),
+					},
+					{
+						Config:   config,
+						PlanOnly: true,
+					},
+				},
+			})
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	defaultRetryAttempts        = 10
+	defaultRetryInitialInterval = time.Second
+	defaultRetryMaxInterval     = 30 * time.Second
+)
+
+// retrySettings control how connecting to the database is retried, the zero
+// value makes a single attempt.
+type retrySettings struct {
+	Attempts        int64
+	InitialInterval time.Duration
+	MaxInterval     time.Duration
+
+	// RetryableErrors are matched against the error message, if empty all
+	// errors are retried.
+	RetryableErrors []*regexp.Regexp
+}
+
+func connectRetryBlock() *tfprotov6.SchemaNestedBlock {
+	return &tfprotov6.SchemaNestedBlock{
+		TypeName: "connect_retry",
+		Nesting:  tfprotov6.SchemaNestedBlockNestingModeSingle,
+		Block: &tfprotov6.SchemaBlock{
+			Description: "Retries opening and pinging the database with exponential backoff, for servers that are " +
+				"still starting up, ie. when created in the same run. Without this block connecting is only attempted once.",
+			DescriptionKind: tfprotov6.StringKindMarkdown,
+			Attributes: []*tfprotov6.SchemaAttribute{
+				{
+					Name:     "attempts",
+					Optional: true,
+					Description: fmt.Sprintf("The maximum number of attempts to connect, including the first. Default is `%d`.",
+						defaultRetryAttempts),
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.Number,
+				},
+				{
+					Name:     "initial_interval",
+					Optional: true,
+					Description: fmt.Sprintf("The duration to wait before the first retry, ie. `500ms`, the interval is "+
+						"doubled after each retry. Default is `%s`.", defaultRetryInitialInterval),
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.String,
+				},
+				{
+					Name:            "max_interval",
+					Optional:        true,
+					Description:     fmt.Sprintf("The maximum duration to wait between retries. Default is `%s`.", defaultRetryMaxInterval),
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.String,
+				},
+				{
+					Name:     "retryable_errors",
+					Optional: true,
+					Description: "Regular expressions matched against the connection error, only matching errors are " +
+						"retried. By default all errors are retried.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type: tftypes.List{
+						ElementType: tftypes.String,
+					},
+				},
+			},
+		},
+	}
+}
+
+func retryFromValue(v tftypes.Value) (retrySettings, error) {
+	r := retrySettings{
+		Attempts:        defaultRetryAttempts,
+		InitialInterval: defaultRetryInitialInterval,
+		MaxInterval:     defaultRetryMaxInterval,
+	}
+
+	var values map[string]tftypes.Value
+	err := v.As(&values)
+	if err != nil {
+		return r, err
+	}
+
+	if attempts := values["attempts"]; !attempts.IsNull() {
+		r.Attempts, err = int64FromValue(attempts)
+		if err != nil || r.Attempts < 1 {
+			return r, attributeErrorf("attempts", "attempts must be a positive integer")
+		}
+	}
+
+	for name, target := range map[string]*time.Duration{
+		"initial_interval": &r.InitialInterval,
+		"max_interval":     &r.MaxInterval,
+	} {
+		if values[name].IsNull() {
+			continue
+		}
+
+		*target, err = durationFromValue(values[name])
+		if err != nil {
+			return r, attributeErrorf(name, "invalid %s: %w", name, err)
+		}
+	}
+
+	if r.MaxInterval < r.InitialInterval {
+		return r, attributeErrorf("max_interval", "max_interval can't be less than initial_interval")
+	}
+
+	if errs := values["retryable_errors"]; !errs.IsNull() {
+		var patterns []tftypes.Value
+		err = errs.As(&patterns)
+		if err != nil {
+			return r, attributeErrorf("retryable_errors", "unable to read retryable_errors: %w", err)
+		}
+
+		for _, pv := range patterns {
+			var pattern string
+			err = pv.As(&pattern)
+			if err != nil {
+				return r, attributeErrorf("retryable_errors", "unable to read retryable_errors: %w", err)
+			}
+
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return r, attributeErrorf("retryable_errors", "invalid retryable_errors pattern %q: %w", pattern, err)
+			}
+			r.RetryableErrors = append(r.RetryableErrors, re)
+		}
+	}
+
+	return r, nil
+}
+
+// retryable returns whether the error matches any of the retryable errors.
+func (r retrySettings) retryable(err error) bool {
+	if len(r.RetryableErrors) == 0 {
+		return true
+	}
+
+	msg := err.Error()
+	for _, re := range r.RetryableErrors {
+		if re.MatchString(msg) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// interval returns the backoff before the given retry, starting at 1.
+func (r retrySettings) interval(retry int64) time.Duration {
+	d := r.InitialInterval
+	for i := int64(1); i < retry; i++ {
+		d *= 2
+		if d >= r.MaxInterval {
+			return r.MaxInterval
+		}
+	}
+
+	if d > r.MaxInterval {
+		return r.MaxInterval
+	}
+	return d
+}
+
+// do calls fn until it succeeds, returns a non-retryable error, or the attempts
+// are exhausted, in which case the last error is returned.
+func (r retrySettings) do(ctx context.Context, fn func() error) error {
+	for attempt := int64(1); ; attempt++ {
+		err := fn()
+		if err == nil || attempt >= r.Attempts || !r.retryable(err) {
+			return err
+		}
+
+		wait := r.interval(attempt)
+		tflog.Warn(ctx, "Unable to connect to the database, retrying.", map[string]interface{}{
+			"attempt": attempt,
+			"wait":    wait.String(),
+			"error":   err.Error(),
+		})
+
+		t := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return err
+		case <-t.C:
+		}
+	}
+}
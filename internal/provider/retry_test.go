@@ -0,0 +1,160 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestRetrySettings_interval(t *testing.T) {
+	r := retrySettings{
+		InitialInterval: time.Second,
+		MaxInterval:     5 * time.Second,
+	}
+
+	for retry, expected := range map[int64]time.Duration{
+		1: time.Second,
+		2: 2 * time.Second,
+		3: 4 * time.Second,
+		4: 5 * time.Second,
+		9: 5 * time.Second,
+	} {
+		if actual := r.interval(retry); actual != expected {
+			t.Errorf("retry %d: expected %s, got %s", retry, expected, actual)
+		}
+	}
+}
+
+func TestRetrySettings_do(t *testing.T) {
+	refused := errors.New("dial tcp 127.0.0.1:5432: connect: connection refused")
+	denied := errors.New("password authentication failed for user \"tf\"")
+
+	for name, c := range map[string]struct {
+		settings retrySettings
+		errs     []error
+		calls    int
+		err      error
+	}{
+		"zero value": {
+			retrySettings{},
+			[]error{refused, nil},
+			1,
+			refused,
+		},
+		"succeeds": {
+			retrySettings{Attempts: 5, InitialInterval: time.Millisecond, MaxInterval: time.Millisecond},
+			[]error{refused, refused, nil},
+			3,
+			nil,
+		},
+		"exhausted": {
+			retrySettings{Attempts: 3, InitialInterval: time.Millisecond, MaxInterval: time.Millisecond},
+			[]error{refused, refused, refused, nil},
+			3,
+			refused,
+		},
+		"not retryable": {
+			retrySettings{
+				Attempts:        5,
+				InitialInterval: time.Millisecond,
+				MaxInterval:     time.Millisecond,
+				RetryableErrors: []*regexp.Regexp{regexp.MustCompile("connection refused")},
+			},
+			[]error{refused, denied, nil},
+			2,
+			denied,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			calls := 0
+			err := c.settings.do(context.Background(), func() error {
+				err := c.errs[calls]
+				calls++
+				return err
+			})
+			if err != c.err {
+				t.Fatalf("expected error %v, got %v", c.err, err)
+			}
+			if calls != c.calls {
+				t.Fatalf("expected %d calls, got %d", c.calls, calls)
+			}
+		})
+	}
+
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		calls := 0
+		err := retrySettings{Attempts: 5, InitialInterval: time.Hour, MaxInterval: time.Hour}.do(ctx, func() error {
+			calls++
+			return refused
+		})
+		if err != refused || calls != 1 {
+			t.Fatalf("expected a single call returning %v, got %d calls returning %v", refused, calls, err)
+		}
+	})
+}
+
+func TestRetryFromValue(t *testing.T) {
+	ty := connectRetryBlock().Block.ValueType().(tftypes.Object)
+
+	value := func(attrs map[string]tftypes.Value) tftypes.Value {
+		values := map[string]tftypes.Value{}
+		for name, attrTy := range ty.AttributeTypes {
+			values[name] = tftypes.NewValue(attrTy, nil)
+		}
+		for name, v := range attrs {
+			values[name] = v
+		}
+		return tftypes.NewValue(ty, values)
+	}
+
+	r, err := retryFromValue(value(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Attempts != defaultRetryAttempts || r.InitialInterval != defaultRetryInitialInterval || r.MaxInterval != defaultRetryMaxInterval {
+		t.Fatalf("expected defaults, got %+v", r)
+	}
+
+	r, err = retryFromValue(value(map[string]tftypes.Value{
+		"attempts":         tftypes.NewValue(tftypes.Number, 3),
+		"initial_interval": tftypes.NewValue(tftypes.String, "500ms"),
+		"max_interval":     tftypes.NewValue(tftypes.String, "2s"),
+		"retryable_errors": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+			tftypes.NewValue(tftypes.String, "connection refused"),
+		}),
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Attempts != 3 || r.InitialInterval != 500*time.Millisecond || r.MaxInterval != 2*time.Second || len(r.RetryableErrors) != 1 {
+		t.Fatalf("unexpected settings %+v", r)
+	}
+
+	for name, attrs := range map[string]map[string]tftypes.Value{
+		"zero attempts":    {"attempts": tftypes.NewValue(tftypes.Number, 0)},
+		"invalid duration": {"initial_interval": tftypes.NewValue(tftypes.String, "soon")},
+		"max below initial": {
+			"initial_interval": tftypes.NewValue(tftypes.String, "1m"),
+			"max_interval":     tftypes.NewValue(tftypes.String, "1s"),
+		},
+		"invalid pattern": {
+			"retryable_errors": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+				tftypes.NewValue(tftypes.String, "("),
+			}),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := retryFromValue(value(attrs))
+			if err == nil {
+				t.Fatal("expected error")
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package provider
+
+import (
+	"context"
+	"sort"
+)
+
+// role is a database role or user, as configured or introspected. Passwords
+// can't be read back, so Password is only set when configured.
+type role struct {
+	Name     string
+	Login    bool
+	Password *string
+
+	// Roles are the roles the role is a member of, and so inherits the
+	// privileges of.
+	Roles []string
+
+	// ConnectionLimit is the maximum number of concurrent connections, -1 for
+	// unlimited.
+	ConnectionLimit int64
+}
+
+// roleDialect is implemented by the dialects that support managing roles.
+type roleDialect interface {
+	Dialect
+
+	// ReadRole introspects the role, returning nil if it does not exist.
+	ReadRole(ctx context.Context, db dbQueryer, name string) (*role, error)
+
+	CreateRole(r role) ([]string, error)
+	AlterRole(prior, planned role) ([]string, error)
+	DropRole(r role) []string
+
+	// RoleRequiresReplace returns the attributes of the role that can't be
+	// changed in place.
+	RoleRequiresReplace(prior, planned role) []string
+}
+
+// diffStrings returns the strings only in planned and those only in prior,
+// both sorted.
+func diffStrings(prior, planned []string) (added, removed []string) {
+	inPrior := map[string]bool{}
+	for _, s := range prior {
+		inPrior[s] = true
+	}
+	inPlanned := map[string]bool{}
+	for _, s := range planned {
+		inPlanned[s] = true
+		if !inPrior[s] {
+			added = append(added, s)
+		}
+	}
+	for _, s := range prior {
+		if !inPlanned[s] {
+			removed = append(removed, s)
+		}
+	}
+
+	sort.Strings(added)
+	sort.Strings(removed)
+	return added, removed
+}
@@ -0,0 +1,157 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDiffStrings(t *testing.T) {
+	added, removed := diffStrings([]string{"c", "a", "b"}, []string{"d", "b", "a", "e"})
+	if diff := cmp.Diff([]string{"d", "e"}, added); diff != "" {
+		t.Errorf("unexpected added (-expected +actual):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"c"}, removed); diff != "" {
+		t.Errorf("unexpected removed (-expected +actual):\n%s", diff)
+	}
+}
+
+func TestCreateRole(t *testing.T) {
+	password := "s'ecret"
+
+	for name, c := range map[string]struct {
+		dialect  roleDialect
+		role     role
+		expected []string
+	}{
+		"pgx": {
+			postgresDialect{},
+			role{Name: "app", Login: true, Password: &password, Roles: []string{"reader"}, ConnectionLimit: 5},
+			[]string{
+				`CREATE ROLE "app" WITH LOGIN CONNECTION LIMIT 5 PASSWORD 's''ecret'`,
+				`GRANT "reader" TO "app"`,
+			},
+		},
+		"pgx group": {
+			postgresDialect{},
+			role{Name: "reader", ConnectionLimit: -1},
+			[]string{`CREATE ROLE "reader" WITH NOLOGIN CONNECTION LIMIT -1`},
+		},
+		"mysql": {
+			mysqlDialect{},
+			role{Name: "app", Login: true, Password: &password, Roles: []string{"reader"}, ConnectionLimit: 5},
+			[]string{
+				"CREATE USER 'app'@'%' IDENTIFIED BY 's''ecret' WITH MAX_USER_CONNECTIONS 5 ACCOUNT UNLOCK",
+				"GRANT 'reader'@'%' TO 'app'@'%'",
+				"SET DEFAULT ROLE ALL TO 'app'@'%'",
+			},
+		},
+		"sqlserver": {
+			sqlServerDialect{},
+			role{Name: "app", Login: true, Password: &password, Roles: []string{"reader"}, ConnectionLimit: -1},
+			[]string{
+				"CREATE LOGIN [app] WITH PASSWORD = N's''ecret'",
+				"CREATE USER [app] FOR LOGIN [app]",
+				"ALTER ROLE [reader] ADD MEMBER [app]",
+			},
+		},
+		"sqlserver group": {
+			sqlServerDialect{},
+			role{Name: "reader", ConnectionLimit: -1},
+			[]string{"CREATE ROLE [reader]"},
+		},
+		"azuresql": {
+			azureSQLDialect{},
+			role{Name: "app", Login: true, Password: &password, ConnectionLimit: -1},
+			[]string{"CREATE USER [app] WITH PASSWORD = N's''ecret'"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			actual, err := c.dialect.CreateRole(c.role)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(c.expected, actual); diff != "" {
+				t.Fatalf("unexpected statements (-expected +actual):\n%s", diff)
+			}
+		})
+	}
+
+	for name, c := range map[string]struct {
+		dialect roleDialect
+		role    role
+	}{
+		"sqlserver connection limit": {sqlServerDialect{}, role{Name: "app", Login: true, Password: &password, ConnectionLimit: 5}},
+		"sqlserver login password":   {sqlServerDialect{}, role{Name: "app", Login: true, ConnectionLimit: -1}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := c.dialect.CreateRole(c.role)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
+
+func TestAlterRole(t *testing.T) {
+	oldPassword, newPassword := "old", "new"
+	prior := role{Name: "app", Login: true, Password: &oldPassword, Roles: []string{"reader", "auditor"}, ConnectionLimit: -1}
+	planned := role{Name: "app", Login: true, Password: &newPassword, Roles: []string{"reader", "writer"}, ConnectionLimit: -1}
+
+	for name, c := range map[string]struct {
+		dialect  roleDialect
+		expected []string
+	}{
+		"pgx": {
+			postgresDialect{},
+			[]string{
+				`ALTER ROLE "app" WITH PASSWORD 'new'`,
+				`REVOKE "auditor" FROM "app"`,
+				`GRANT "writer" TO "app"`,
+			},
+		},
+		"mysql": {
+			mysqlDialect{},
+			[]string{
+				"ALTER USER 'app'@'%' IDENTIFIED BY 'new'",
+				"REVOKE 'auditor'@'%' FROM 'app'@'%'",
+				"GRANT 'writer'@'%' TO 'app'@'%'",
+				"SET DEFAULT ROLE ALL TO 'app'@'%'",
+			},
+		},
+		"sqlserver": {
+			sqlServerDialect{},
+			[]string{
+				"ALTER LOGIN [app] WITH PASSWORD = N'new'",
+				"ALTER ROLE [auditor] DROP MEMBER [app]",
+				"ALTER ROLE [writer] ADD MEMBER [app]",
+			},
+		},
+		"azuresql": {
+			azureSQLDialect{},
+			[]string{
+				"ALTER USER [app] WITH PASSWORD = N'new'",
+				"ALTER ROLE [auditor] DROP MEMBER [app]",
+				"ALTER ROLE [writer] ADD MEMBER [app]",
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			actual, err := c.dialect.AlterRole(prior, planned)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(c.expected, actual); diff != "" {
+				t.Fatalf("unexpected statements (-expected +actual):\n%s", diff)
+			}
+
+			unchanged, err := c.dialect.AlterRole(planned, planned)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(unchanged) > 0 {
+				t.Fatalf("expected no statements for an unchanged role, got %v", unchanged)
+			}
+		})
+	}
+}
@@ -0,0 +1,219 @@
+package provider
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// tableRow maps the column names of a row to their values as strings, nil is
+// NULL. Values are converted by the database.
+type tableRow map[string]*string
+
+// rowsDialect is implemented by the dialects that support upserting rows.
+type rowsDialect interface {
+	Dialect
+
+	// UpsertStatement returns the statement inserting a row, or updating the
+	// other columns of the row with the same key. The values are bound to
+	// placeholders in the order of columns.
+	UpsertStatement(table string, columns, keyColumns []string) string
+}
+
+// rowColumns returns the column names of the row, sorted.
+func rowColumns(row tableRow) []string {
+	columns := make([]string, 0, len(row))
+	for c := range row {
+		columns = append(columns, c)
+	}
+	sort.Strings(columns)
+	return columns
+}
+
+// rowArgs returns the values of the columns of the row as query arguments.
+func rowArgs(row tableRow, columns []string) []interface{} {
+	args := make([]interface{}, len(columns))
+	for i, c := range columns {
+		if v := row[c]; v != nil {
+			args[i] = *v
+		}
+	}
+	return args
+}
+
+// rowKey identifies the row by the values of its key columns.
+func rowKey(row tableRow, keyColumns []string) string {
+	values := make([]string, len(keyColumns))
+	for i, c := range keyColumns {
+		if v := row[c]; v != nil {
+			values[i] = fmt.Sprintf("%q", *v)
+		}
+	}
+	return strings.Join(values, ",")
+}
+
+func equalRows(a, b tableRow) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for c, v := range a {
+		other, ok := b[c]
+		if !ok || !equalStringPtr(v, other) {
+			return false
+		}
+	}
+	return true
+}
+
+// keyCondition returns the WHERE condition matching the key columns, bound to
+// placeholders from first.
+func keyCondition(d Dialect, keyColumns []string, first int) string {
+	conds := make([]string, len(keyColumns))
+	for i, c := range keyColumns {
+		conds[i] = fmt.Sprintf("%s = %s", d.QuoteIdentifier(c), d.Placeholder(first+i))
+	}
+	return strings.Join(conds, " AND ")
+}
+
+func deleteRowStatement(d Dialect, table string, keyColumns []string) string {
+	return fmt.Sprintf("DELETE FROM %s WHERE %s", table, keyCondition(d, keyColumns, 1))
+}
+
+// rowsStatement is a statement and its arguments.
+type rowsStatement struct {
+	query string
+	args  []interface{}
+}
+
+// rowsStatements returns the statements deleting the prior rows that are no
+// longer planned, and upserting the planned rows that changed.
+func rowsStatements(d rowsDialect, table string, keyColumns []string, prior, planned []tableRow) []rowsStatement {
+	plannedByKey := map[string]tableRow{}
+	for _, row := range planned {
+		plannedByKey[rowKey(row, keyColumns)] = row
+	}
+	priorByKey := map[string]tableRow{}
+	for _, row := range prior {
+		priorByKey[rowKey(row, keyColumns)] = row
+	}
+
+	var stmts []rowsStatement
+	for _, row := range prior {
+		if _, ok := plannedByKey[rowKey(row, keyColumns)]; !ok {
+			stmts = append(stmts, rowsStatement{
+				query: deleteRowStatement(d, table, keyColumns),
+				args:  rowArgs(row, keyColumns),
+			})
+		}
+	}
+	for _, row := range planned {
+		if priorRow, ok := priorByKey[rowKey(row, keyColumns)]; ok && equalRows(priorRow, row) {
+			continue
+		}
+		columns := rowColumns(row)
+		stmts = append(stmts, rowsStatement{
+			query: d.UpsertStatement(table, columns, keyColumns),
+			args:  rowArgs(row, columns),
+		})
+	}
+
+	return stmts
+}
+
+// readRow reads the columns of the prior row from the row with the same key,
+// returning nil if there is none. Values that are spelled differently by the
+// database but compare equal to the prior ones, such as numbers or dates, keep
+// the prior spelling.
+func readRow(ctx context.Context, db dbQueryer, d Dialect, table string, keyColumns []string, prior tableRow) (tableRow, error) {
+	columns := rowColumns(prior)
+	where := keyCondition(d, keyColumns, 1)
+	keyArgs := rowArgs(prior, keyColumns)
+
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE %s", quoteIdentifiers(d, columns), table, where)
+	rows, err := db.QueryContext(ctx, query, keyArgs...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return nil, rows.Err()
+	}
+
+	values := make([]sql.NullString, len(columns))
+	dest := make([]interface{}, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	err = rows.Scan(dest...)
+	if err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	row := tableRow{}
+	for i, c := range columns {
+		v := values[i]
+		switch {
+		case !v.Valid:
+			row[c] = nil
+		case prior[c] != nil && *prior[c] == v.String:
+			row[c] = prior[c]
+		case prior[c] != nil:
+			equal, err := columnEquals(ctx, db, d, table, keyColumns, keyArgs, c, *prior[c])
+			if err != nil {
+				return nil, err
+			}
+			if equal {
+				row[c] = prior[c]
+			} else {
+				row[c] = &v.String
+			}
+		default:
+			row[c] = &v.String
+		}
+	}
+
+	return row, nil
+}
+
+// columnEquals compares the column of the row with the value in the database,
+// converting the value to the type of the column.
+func columnEquals(ctx context.Context, db dbQueryer, d Dialect, table string, keyColumns []string, keyArgs []interface{}, column, value string) (bool, error) {
+	query := fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE %s AND %s = %s",
+		table, keyCondition(d, keyColumns, 1), d.QuoteIdentifier(column), d.Placeholder(len(keyColumns)+1))
+	rows, err := db.QueryContext(ctx, query, append(append([]interface{}{}, keyArgs...), value)...)
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+
+	var count int64
+	if rows.Next() {
+		err = rows.Scan(&count)
+		if err != nil {
+			return false, err
+		}
+	}
+	return count > 0, rows.Err()
+}
+
+// upsertParts returns the quoted columns, their placeholders and the columns
+// that are not part of the key.
+func upsertParts(d Dialect, columns, keyColumns []string) (quoted string, placeholders string, others []string) {
+	isKey := map[string]bool{}
+	for _, c := range keyColumns {
+		isKey[c] = true
+	}
+
+	values := make([]string, len(columns))
+	for i, c := range columns {
+		values[i] = d.Placeholder(i + 1)
+		if !isKey[c] {
+			others = append(others, c)
+		}
+	}
+	return quoteIdentifiers(d, columns), strings.Join(values, ", "), others
+}
@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestUpsertStatement(t *testing.T) {
+	for name, c := range map[string]struct {
+		dialect  rowsDialect
+		columns  []string
+		expected string
+	}{
+		"pgx": {
+			postgresDialect{},
+			[]string{"code", "name", "enabled"},
+			`INSERT INTO "flags" ("code", "name", "enabled") VALUES ($1, $2, $3) ON CONFLICT ("code") DO UPDATE SET "name" = EXCLUDED."name", "enabled" = EXCLUDED."enabled"`,
+		},
+		"pgx key only": {
+			postgresDialect{},
+			[]string{"code"},
+			`INSERT INTO "flags" ("code") VALUES ($1) ON CONFLICT ("code") DO NOTHING`,
+		},
+		"mysql": {
+			mysqlDialect{},
+			[]string{"code", "name"},
+			"INSERT INTO `flags` (`code`, `name`) VALUES (?, ?) ON DUPLICATE KEY UPDATE `name` = VALUES(`name`)",
+		},
+		"mysql key only": {
+			mysqlDialect{},
+			[]string{"code"},
+			"INSERT INTO `flags` (`code`) VALUES (?) ON DUPLICATE KEY UPDATE `code` = `code`",
+		},
+		"sqlserver": {
+			sqlServerDialect{},
+			[]string{"code", "name"},
+			"MERGE INTO [flags] AS t USING (VALUES (@p1, @p2)) AS s ([code], [name]) ON t.[code] = s.[code] WHEN MATCHED THEN UPDATE SET [name] = s.[name] WHEN NOT MATCHED THEN INSERT ([code], [name]) VALUES (s.[code], s.[name]);",
+		},
+		"sqlserver key only": {
+			sqlServerDialect{},
+			[]string{"code"},
+			"MERGE INTO [flags] AS t USING (VALUES (@p1)) AS s ([code]) ON t.[code] = s.[code] WHEN NOT MATCHED THEN INSERT ([code]) VALUES (s.[code]);",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			actual := c.dialect.UpsertStatement(qualifiedName(c.dialect, "", "flags"), c.columns, []string{"code"})
+			if actual != c.expected {
+				t.Fatalf("expected:\n%s\ngot:\n%s", c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestRowsStatements(t *testing.T) {
+	s := func(s string) *string { return &s }
+
+	prior := []tableRow{
+		{"code": s("a"), "name": s("A")},
+		{"code": s("b"), "name": s("B")},
+		{"code": s("c"), "name": s("C")},
+	}
+	planned := []tableRow{
+		{"code": s("a"), "name": s("A")},
+		{"code": s("c"), "name": nil},
+		{"code": s("d"), "name": s("D")},
+	}
+
+	expected := []rowsStatement{
+		{`DELETE FROM "flags" WHERE "code" = $1`, []interface{}{"b"}},
+		{`INSERT INTO "flags" ("code", "name") VALUES ($1, $2) ON CONFLICT ("code") DO UPDATE SET "name" = EXCLUDED."name"`, []interface{}{"c", nil}},
+		{`INSERT INTO "flags" ("code", "name") VALUES ($1, $2) ON CONFLICT ("code") DO UPDATE SET "name" = EXCLUDED."name"`, []interface{}{"d", "D"}},
+	}
+
+	actual := rowsStatements(postgresDialect{}, `"flags"`, []string{"code"}, prior, planned)
+	if diff := cmp.Diff(expected, actual, cmp.AllowUnexported(rowsStatement{})); diff != "" {
+		t.Fatalf("unexpected statements (-expected +actual):\n%s", diff)
+	}
+}
@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// Server products reported by the sql_server data source.
+const (
+	productPostgres    = "postgres"
+	productCockroachDB = "cockroachdb"
+	productMySQL       = "mysql"
+	productMariaDB     = "mariadb"
+	productSQLServer   = "sqlserver"
+	productAzureSQL    = "azuresql"
+)
+
+// serverInfo describes the server of a connection, and the features that
+// differ between products sharing a driver.
+type serverInfo struct {
+	Product string
+	Version string
+	Major   int64
+	Minor   int64
+
+	// Database is nil if the connection has no current database.
+	Database *string
+	User     string
+
+	TransactionalDDL bool
+	AdvisoryLocks    bool
+	IfNotExists      bool
+}
+
+// serverInfoDialect is implemented by the dialects that can describe their
+// server.
+type serverInfoDialect interface {
+	Dialect
+
+	ReadServerInfo(ctx context.Context, db dbQueryer) (*serverInfo, error)
+}
+
+var versionNumber = regexp.MustCompile(`(\d+)\.(\d+)`)
+
+// parseVersion returns the major and minor version of the first version
+// number in the string, or zeros if there is none.
+func parseVersion(s string) (major, minor int64) {
+	m := versionNumber.FindStringSubmatch(s)
+	if m == nil {
+		return 0, 0
+	}
+	major, _ = strconv.ParseInt(m[1], 10, 64)
+	minor, _ = strconv.ParseInt(m[2], 10, 64)
+	return major, minor
+}
+
+// scanServerInfo scans the single row of the query in to dest.
+func scanServerInfo(ctx context.Context, db dbQueryer, query string, dest ...interface{}) error {
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return err
+		}
+		return fmt.Errorf("no rows returned")
+	}
+	err = rows.Scan(dest...)
+	if err != nil {
+		return err
+	}
+	return rows.Err()
+}
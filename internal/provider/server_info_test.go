@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseVersion(t *testing.T) {
+	for version, expected := range map[string][2]int64{
+		"PostgreSQL 16.2 (Debian 16.2-1.pgdg120+2) on x86_64-pc-linux-gnu": {16, 2},
+		"CockroachDB CCL v23.1.11 (x86_64-pc-linux-gnu, built 2023/09/27)": {23, 1},
+		"8.0.35":                          {8, 0},
+		"10.11.6-MariaDB-1:10.11.6+maria": {10, 11},
+		"15.0.4345.5":                     {15, 0},
+		"unknown":                         {0, 0},
+	} {
+		t.Run(version, func(t *testing.T) {
+			major, minor := parseVersion(version)
+			if major != expected[0] || minor != expected[1] {
+				t.Fatalf("expected %d.%d, got %d.%d", expected[0], expected[1], major, minor)
+			}
+		})
+	}
+}
+
+func TestDescribeServer(t *testing.T) {
+	for name, c := range map[string]struct {
+		describe func(*serverInfo)
+		version  string
+		expected serverInfo
+	}{
+		"postgres": {
+			describePostgres,
+			"PostgreSQL 16.2 on x86_64-pc-linux-gnu",
+			serverInfo{Product: productPostgres, Major: 16, Minor: 2, TransactionalDDL: true, AdvisoryLocks: true, IfNotExists: true},
+		},
+		"cockroachdb": {
+			describePostgres,
+			"CockroachDB CCL v23.1.11 (x86_64-pc-linux-gnu)",
+			serverInfo{Product: productCockroachDB, Major: 23, Minor: 1, IfNotExists: true},
+		},
+		"mysql": {
+			describeMySQL,
+			"8.0.35",
+			serverInfo{Product: productMySQL, Major: 8, Minor: 0, AdvisoryLocks: true, IfNotExists: true},
+		},
+		"mariadb": {
+			describeMySQL,
+			"10.11.6-MariaDB",
+			serverInfo{Product: productMariaDB, Major: 10, Minor: 11, AdvisoryLocks: true, IfNotExists: true},
+		},
+		"sqlserver": {
+			func(info *serverInfo) { describeSQLServer(info, "15.0.4345.5", 3) },
+			"Microsoft SQL Server 2019 (RTM-CU24) - 15.0.4345.5 (X64)",
+			serverInfo{Product: productSQLServer, Major: 15, Minor: 0, TransactionalDDL: true, AdvisoryLocks: true},
+		},
+		"azuresql": {
+			func(info *serverInfo) { describeSQLServer(info, "12.0.2000.8", 5) },
+			"Microsoft SQL Azure (RTM) - 12.0.2000.8",
+			serverInfo{Product: productAzureSQL, Major: 12, Minor: 0, TransactionalDDL: true, AdvisoryLocks: true},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			actual := serverInfo{Version: c.version}
+			c.describe(&actual)
+
+			c.expected.Version = c.version
+			if diff := cmp.Diff(c.expected, actual); diff != "" {
+				t.Fatalf("unexpected server info (-expected +actual):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,253 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// contextDialer is implemented by custom dialers that can be registered with
+// each of the drivers.
+type contextDialer interface {
+	DialContext(ctx context.Context, network, addr string) (net.Conn, error)
+}
+
+// sshTunnelSettings are the settings of the bastion host that database
+// connections are tunnelled through.
+type sshTunnelSettings struct {
+	Host       string
+	User       string
+	PrivateKey string
+	KnownHosts string
+}
+
+func sshTunnelBlock() *tfprotov6.SchemaNestedBlock {
+	return &tfprotov6.SchemaNestedBlock{
+		TypeName: "ssh_tunnel",
+		Nesting:  tfprotov6.SchemaNestedBlockNestingModeSingle,
+		Block: &tfprotov6.SchemaBlock{
+			Description: "Connects to the database through an SSH bastion host. The database host is resolved by the " +
+				"bastion, so it can be a private name or address.",
+			DescriptionKind: tfprotov6.StringKindMarkdown,
+			Attributes: []*tfprotov6.SchemaAttribute{
+				{
+					Name:            "host",
+					Required:        true,
+					Description:     "The host of the bastion, optionally with a port, ie. `bastion.example.com:2222`. The default port is `22`.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.String,
+				},
+				{
+					Name:            "user",
+					Required:        true,
+					Description:     "The user to authenticate to the bastion as.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.String,
+				},
+				{
+					Name:            "private_key",
+					Required:        true,
+					Sensitive:       true,
+					Description:     "The private key to authenticate with, either as PEM contents or the path to a PEM file.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.String,
+				},
+				{
+					Name:     "known_hosts",
+					Optional: true,
+					Description: "The known hosts used to verify the bastion's host key, either as the contents or the path of " +
+						"a `known_hosts` file. Defaults to `~/.ssh/known_hosts`.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.String,
+				},
+			},
+		},
+	}
+}
+
+func sshTunnelFromValue(v tftypes.Value) (sshTunnelSettings, error) {
+	var s sshTunnelSettings
+
+	var values map[string]tftypes.Value
+	err := v.As(&values)
+	if err != nil {
+		return s, err
+	}
+
+	for name, target := range map[string]*string{
+		"host":        &s.Host,
+		"user":        &s.User,
+		"private_key": &s.PrivateKey,
+		"known_hosts": &s.KnownHosts,
+	} {
+		err = values[name].As(target)
+		if err != nil {
+			return s, attributeErrorf(name, "unable to read %s: %w", name, err)
+		}
+	}
+
+	return s, nil
+}
+
+// Tunnel builds the tunnel for the settings, the bastion is not connected to
+// until the first connection is dialed.
+func (s sshTunnelSettings) Tunnel() (*sshTunnel, error) {
+	keyPEM, err := readPEM(s.PrivateKey)
+	if err != nil {
+		return nil, attributeErrorf("private_key", "unable to read private_key: %w", err)
+	}
+
+	signer, err := ssh.ParsePrivateKey(keyPEM)
+	if err != nil {
+		return nil, attributeErrorf("private_key", "unable to parse private_key: %w", err)
+	}
+
+	hostKeyCallback, err := knownHostsCallback(s.KnownHosts)
+	if err != nil {
+		return nil, attributeErrorf("known_hosts", "unable to read known_hosts: %w", err)
+	}
+
+	addr := s.Host
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		addr = net.JoinHostPort(addr, "22")
+	}
+
+	return &sshTunnel{
+		addr: addr,
+		config: &ssh.ClientConfig{
+			User:            s.User,
+			Auth:            []ssh.AuthMethod{ssh.PublicKeys(signer)},
+			HostKeyCallback: hostKeyCallback,
+		},
+	}, nil
+}
+
+// knownHostsCallback verifies host keys against the known hosts, which are
+// either the contents or path of a known_hosts file.
+func knownHostsCallback(knownHosts string) (ssh.HostKeyCallback, error) {
+	if knownHosts == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+		return knownhosts.New(filepath.Join(home, ".ssh", "known_hosts"))
+	}
+
+	// known_hosts lines always contain spaces, paths usually don't
+	if !strings.ContainsAny(knownHosts, " \n") {
+		return knownhosts.New(knownHosts)
+	}
+
+	// the knownhosts package only reads files, it is read immediately so the
+	// file can be removed after
+	f, err := os.CreateTemp("", "known_hosts")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(f.Name())
+
+	_, err = f.WriteString(knownHosts)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return knownhosts.New(f.Name())
+}
+
+// sshTunnel dials connections through an SSH bastion, the SSH connection is
+// shared by all the connections and re-established if it is lost.
+type sshTunnel struct {
+	addr   string
+	config *ssh.ClientConfig
+
+	mu     sync.Mutex
+	client *ssh.Client
+}
+
+var _ contextDialer = (*sshTunnel)(nil)
+
+func (t *sshTunnel) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	client, err := t.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := client.DialContext(ctx, network, addr)
+	if err == nil {
+		return conn, nil
+	}
+
+	var openErr *ssh.OpenChannelError
+	if errors.As(err, &openErr) {
+		// the bastion is reachable but was unable to connect to the database
+		return nil, fmt.Errorf("unable to dial %s through ssh tunnel: %w", addr, err)
+	}
+
+	// the bastion connection may have been dropped, so retry once with a new one
+	t.reset(client)
+
+	client, err = t.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err = client.DialContext(ctx, network, addr)
+	if err != nil {
+		return nil, fmt.Errorf("unable to dial %s through ssh tunnel: %w", addr, err)
+	}
+
+	return conn, nil
+}
+
+// HostName implements the mssql HostDialer interface, so that the database
+// host is resolved by the bastion instead of locally.
+func (t *sshTunnel) HostName() string {
+	return t.addr
+}
+
+func (t *sshTunnel) connect(ctx context.Context) (*ssh.Client, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.client != nil {
+		return t.client, nil
+	}
+
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", t.addr)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to ssh bastion: %w", err)
+	}
+
+	c, chans, reqs, err := ssh.NewClientConn(conn, t.addr, t.config)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("unable to connect to ssh bastion: %w", err)
+	}
+
+	t.client = ssh.NewClient(c, chans, reqs)
+	return t.client, nil
+}
+
+func (t *sshTunnel) reset(client *ssh.Client) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.client == client {
+		t.client.Close()
+		t.client = nil
+	}
+}
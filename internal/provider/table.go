@@ -0,0 +1,396 @@
+package provider
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// table is the definition of a table, either as configured or as introspected
+// from the database.
+type table struct {
+	Schema     string
+	Name       string
+	Columns    []tableColumn
+	PrimaryKey []string
+	Indexes    []tableIndex
+}
+
+type tableColumn struct {
+	Name     string
+	Type     string
+	Nullable bool
+
+	// Default is the SQL expression of the default value, nil if the column
+	// has none.
+	Default *string
+}
+
+type tableIndex struct {
+	Name    string
+	Columns []string
+	Unique  bool
+}
+
+// tableDialect is implemented by the dialects that support managing tables.
+type tableDialect interface {
+	Dialect
+
+	// CurrentSchema returns an expression for the default schema of the
+	// connection, which is the database for MySQL.
+	CurrentSchema() string
+
+	// NormalizeColumnType returns the canonical spelling of a column type, so
+	// that configured types can be compared to those reported by the server.
+	NormalizeColumnType(colType string) string
+
+	// NormalizeDefault returns the canonical spelling of a column default, so
+	// that configured defaults can be compared to those reported by the server.
+	NormalizeDefault(dflt string) string
+
+	// ReadTables returns the names of the base tables of the schema, sorted.
+	ReadTables(ctx context.Context, db dbQueryer, schema string) ([]string, error)
+
+	// ReadColumns returns the columns of the table in order, there are none if
+	// the table does not exist.
+	ReadColumns(ctx context.Context, db dbQueryer, schema, name string) ([]tableColumn, error)
+
+	// ReadIndexes returns the indexes of the table ordered by name, excluding
+	// the primary key and those backing constraints.
+	ReadIndexes(ctx context.Context, db dbQueryer, schema, name string) ([]tableIndex, error)
+
+	AddColumn(schema, name string, c tableColumn) []string
+	AlterColumn(schema, name string, prior, planned tableColumn) []string
+	DropColumn(schema, name string, c tableColumn) []string
+	DropIndex(schema, name string, index tableIndex) string
+}
+
+// qualifiedName quotes and joins the schema and name of an object, without a
+// schema the name is resolved by the database.
+func qualifiedName(d Dialect, schema, name string) string {
+	if schema == "" {
+		return d.QuoteIdentifier(name)
+	}
+	return d.QuoteIdentifier(schema) + "." + d.QuoteIdentifier(name)
+}
+
+func quoteIdentifiers(d Dialect, names []string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = d.QuoteIdentifier(name)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// columnDefinition returns the column as used in CREATE and ALTER TABLE
+// statements.
+func columnDefinition(d Dialect, c tableColumn) string {
+	def := d.QuoteIdentifier(c.Name) + " " + c.Type
+	if c.Nullable {
+		def += " NULL"
+	} else {
+		def += " NOT NULL"
+	}
+	if c.Default != nil {
+		def += " DEFAULT " + *c.Default
+	}
+	return def
+}
+
+func createIndexStatement(d Dialect, schema, name string, index tableIndex) string {
+	create := "CREATE INDEX"
+	if index.Unique {
+		create = "CREATE UNIQUE INDEX"
+	}
+	return fmt.Sprintf("%s %s ON %s (%s)", create, d.QuoteIdentifier(index.Name), qualifiedName(d, schema, name), quoteIdentifiers(d, index.Columns))
+}
+
+// createTableStatements returns the statements to create the table and its
+// indexes.
+func createTableStatements(d tableDialect, t table) []string {
+	defs := make([]string, 0, len(t.Columns)+1)
+	for _, c := range t.Columns {
+		defs = append(defs, columnDefinition(d, c))
+	}
+	if len(t.PrimaryKey) > 0 {
+		defs = append(defs, fmt.Sprintf("PRIMARY KEY (%s)", quoteIdentifiers(d, t.PrimaryKey)))
+	}
+
+	stmts := []string{
+		fmt.Sprintf("CREATE TABLE %s (\n\t%s\n)", qualifiedName(d, t.Schema, t.Name), strings.Join(defs, ",\n\t")),
+	}
+	for _, index := range t.Indexes {
+		stmts = append(stmts, createIndexStatement(d, t.Schema, t.Name, index))
+	}
+	return stmts
+}
+
+func dropTableStatement(d tableDialect, t table) string {
+	return "DROP TABLE " + qualifiedName(d, t.Schema, t.Name)
+}
+
+// alterTableStatements returns the statements to change the columns and
+// indexes of the prior table to those planned. Changes to the name, schema
+// or primary key require the table to be replaced instead.
+func alterTableStatements(d tableDialect, prior, planned table) []string {
+	var stmts []string
+
+	priorColumns := map[string]tableColumn{}
+	for _, c := range prior.Columns {
+		priorColumns[c.Name] = c
+	}
+	plannedColumns := map[string]tableColumn{}
+	for _, c := range planned.Columns {
+		plannedColumns[c.Name] = c
+	}
+	priorIndexes := map[string]tableIndex{}
+	for _, index := range prior.Indexes {
+		priorIndexes[index.Name] = index
+	}
+	plannedIndexes := map[string]tableIndex{}
+	for _, index := range planned.Indexes {
+		plannedIndexes[index.Name] = index
+	}
+
+	// indexes are dropped first as they may reference dropped columns, changed
+	// indexes are dropped and created again
+	for _, index := range prior.Indexes {
+		if p, ok := plannedIndexes[index.Name]; !ok || !sameIndex(index, p) {
+			stmts = append(stmts, d.DropIndex(planned.Schema, planned.Name, index))
+		}
+	}
+
+	for _, c := range prior.Columns {
+		if _, ok := plannedColumns[c.Name]; !ok {
+			stmts = append(stmts, d.DropColumn(planned.Schema, planned.Name, c)...)
+		}
+	}
+
+	for _, c := range planned.Columns {
+		p, ok := priorColumns[c.Name]
+		if !ok {
+			stmts = append(stmts, d.AddColumn(planned.Schema, planned.Name, c)...)
+			continue
+		}
+		if !sameColumn(d, p, c) {
+			stmts = append(stmts, d.AlterColumn(planned.Schema, planned.Name, p, c)...)
+		}
+	}
+
+	for _, index := range planned.Indexes {
+		if p, ok := priorIndexes[index.Name]; !ok || !sameIndex(p, index) {
+			stmts = append(stmts, createIndexStatement(d, planned.Schema, planned.Name, index))
+		}
+	}
+
+	return stmts
+}
+
+func sameColumnType(d tableDialect, a, b string) bool {
+	return d.NormalizeColumnType(a) == d.NormalizeColumnType(b)
+}
+
+func sameDefault(d tableDialect, a, b string) bool {
+	return d.NormalizeDefault(a) == d.NormalizeDefault(b)
+}
+
+// foldDefault lower cases a default expression outside of its string
+// literals, for the dialects where keywords and functions are case
+// insensitive.
+func foldDefault(dflt string) string {
+	var b strings.Builder
+	quoted := false
+	for _, r := range strings.TrimSpace(dflt) {
+		if r == '\'' {
+			quoted = !quoted
+		}
+		if !quoted {
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// trimParens removes the parentheses around a whole default expression, ie.
+// ((0)) is 0 but (1) + (2) is unchanged.
+func trimParens(dflt string) string {
+	for strings.HasPrefix(dflt, "(") && strings.HasSuffix(dflt, ")") {
+		depth, quoted := 0, false
+		for i, r := range dflt {
+			switch {
+			case r == '\'':
+				quoted = !quoted
+			case quoted:
+			case r == '(':
+				depth++
+			case r == ')':
+				depth--
+			}
+			if depth == 0 && i < len(dflt)-1 {
+				return dflt
+			}
+		}
+		dflt = strings.TrimSpace(dflt[1 : len(dflt)-1])
+	}
+	return dflt
+}
+
+// unquoteDefault returns the value of a default that is a single string
+// literal.
+func unquoteDefault(dflt string) (string, bool) {
+	if len(dflt) < 2 || !strings.HasPrefix(dflt, "'") || !strings.HasSuffix(dflt, "'") {
+		return "", false
+	}
+	value := dflt[1 : len(dflt)-1]
+	if strings.Contains(strings.ReplaceAll(value, "''", ""), "'") {
+		return "", false
+	}
+	return strings.ReplaceAll(value, "''", "'"), true
+}
+
+// equalStringPtr compares optional strings, which are equal if both are nil.
+func equalStringPtr(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func sameColumn(d tableDialect, a, b tableColumn) bool {
+	return sameColumnType(d, a.Type, b.Type) && a.Nullable == b.Nullable && equalStringPtr(a.Default, b.Default)
+}
+
+func sameIndex(a, b tableIndex) bool {
+	return a.Unique == b.Unique && strings.Join(a.Columns, "\x00") == strings.Join(b.Columns, "\x00")
+}
+
+// currentSchema returns the default schema of the connection.
+func currentSchema(ctx context.Context, d tableDialect, db dbQueryer) (string, error) {
+	rows, err := db.QueryContext(ctx, "SELECT "+d.CurrentSchema())
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	var schema sql.NullString
+	if rows.Next() {
+		err = rows.Scan(&schema)
+		if err != nil {
+			return "", err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return "", err
+	}
+
+	if schema.String == "" {
+		return "", fmt.Errorf("the connection has no default schema, set schema")
+	}
+	return schema.String, nil
+}
+
+// readTable introspects the table, returning nil if it does not exist.
+func readTable(ctx context.Context, d tableDialect, db dbQueryer, schema, name string) (*table, error) {
+	columns, err := d.ReadColumns(ctx, db, schema, name)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read columns: %w", err)
+	}
+	if len(columns) == 0 {
+		return nil, nil
+	}
+
+	primaryKey, err := readPrimaryKey(ctx, d, db, schema, name)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read primary key: %w", err)
+	}
+
+	indexes, err := d.ReadIndexes(ctx, db, schema, name)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read indexes: %w", err)
+	}
+
+	return &table{
+		Schema:     schema,
+		Name:       name,
+		Columns:    columns,
+		PrimaryKey: primaryKey,
+		Indexes:    indexes,
+	}, nil
+}
+
+func readPrimaryKey(ctx context.Context, d Dialect, db dbQueryer, schema, name string) ([]string, error) {
+	return queryStrings(ctx, db, fmt.Sprintf(`SELECT k.column_name
+FROM information_schema.table_constraints t
+JOIN information_schema.key_column_usage k
+	ON k.constraint_schema = t.constraint_schema
+	AND k.constraint_name = t.constraint_name
+	AND k.table_schema = t.table_schema
+	AND k.table_name = t.table_name
+WHERE t.constraint_type = 'PRIMARY KEY' AND t.table_schema = %s AND t.table_name = %s
+ORDER BY k.ordinal_position`, d.Placeholder(1), d.Placeholder(2)), schema, name)
+}
+
+// scanIndexes reads rows of index name, uniqueness and column name, ordered
+// by index name and then column position.
+func scanIndexes(rows *sql.Rows) ([]tableIndex, error) {
+	defer rows.Close()
+
+	var indexes []tableIndex
+	for rows.Next() {
+		var name, column string
+		var unique bool
+		err := rows.Scan(&name, &unique, &column)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(indexes) == 0 || indexes[len(indexes)-1].Name != name {
+			indexes = append(indexes, tableIndex{Name: name, Unique: unique})
+		}
+		last := &indexes[len(indexes)-1]
+		last.Columns = append(last.Columns, column)
+	}
+
+	return indexes, rows.Err()
+}
+
+var columnTypePattern = regexp.MustCompile(`^([a-z_][a-z0-9_]*(?: [a-z_][a-z0-9_]*)*)\s*(\([^)]*\))?\s*(.*)$`)
+
+// splitColumnType splits a lower cased column type in to its base name, its
+// parameters without the parentheses, and anything after them, ie. array
+// brackets or modifiers.
+func splitColumnType(colType string) (base string, params string, rest string) {
+	colType = strings.Join(strings.Fields(strings.ToLower(colType)), " ")
+
+	m := columnTypePattern.FindStringSubmatch(colType)
+	if m == nil {
+		return colType, "", ""
+	}
+
+	params = strings.TrimSuffix(strings.TrimPrefix(m[2], "("), ")")
+	parts := strings.Split(params, ",")
+	for i, p := range parts {
+		parts[i] = strings.TrimSpace(p)
+	}
+
+	return m[1], strings.Join(parts, ","), m[3]
+}
+
+// joinColumnType is the inverse of splitColumnType.
+func joinColumnType(base, params, rest string) string {
+	t := base
+	if params != "" {
+		t += "(" + params + ")"
+	}
+	if rest != "" {
+		if !strings.HasPrefix(rest, "[") {
+			t += " "
+		}
+		t += rest
+	}
+	return t
+}
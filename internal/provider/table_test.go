@@ -0,0 +1,373 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+func TestNormalizeColumnType(t *testing.T) {
+	for name, c := range map[string]struct {
+		dialect tableDialect
+		same    [][]string
+		differ  [][]string
+	}{
+		"pgx": {
+			postgresDialect{},
+			[][]string{
+				{"int", "integer", "INT4"},
+				{"varchar(255)", "character varying(255)", "VARCHAR ( 255 )"},
+				{"char", "character(1)", "bpchar"},
+				{"timestamptz", "timestamp with time zone"},
+				{"timestamptz(3)", "timestamp(3) with time zone"},
+				{"decimal(10,2)", "numeric(10, 2)"},
+				{"int[]", "integer[3]"},
+				{"bool", "boolean"},
+			},
+			[][]string{
+				{"varchar(10)", "varchar(20)"},
+				{"timestamp", "timestamptz"},
+				{"integer", "bigint"},
+				{"serial", "integer"},
+			},
+		},
+		"mysql": {
+			mysqlDialect{},
+			[][]string{
+				{"int", "int(11)", "INTEGER"},
+				{"int unsigned", "int(10) unsigned"},
+				{"int auto_increment", "int(11) AUTO_INCREMENT"},
+				{"bool", "tinyint(1)"},
+				{"decimal", "decimal(10,0)", "numeric(10)"},
+				{"double precision", "double"},
+			},
+			[][]string{
+				{"tinyint(1)", "tinyint(4)"},
+				{"varchar(10)", "varchar(20)"},
+				{"int", "int unsigned"},
+			},
+		},
+		"sqlserver": {
+			sqlServerDialect{},
+			[][]string{
+				{"int", "INTEGER"},
+				{"varchar", "varchar(1)"},
+				{"nvarchar(MAX)", "nvarchar(max)"},
+				{"decimal", "numeric(18,0)", "decimal(18)"},
+				{"datetime2", "datetime2(7)"},
+				{"float", "double precision", "float(53)"},
+				{"int identity", "int IDENTITY(1, 1)", "int identity(1,1)"},
+			},
+			[][]string{
+				{"datetime2(3)", "datetime2"},
+				{"int identity(1,1)", "int identity(10,1)"},
+				{"int identity(1,1)", "int"},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			for _, types := range c.same {
+				expected := c.dialect.NormalizeColumnType(types[0])
+				for _, ty := range types[1:] {
+					if actual := c.dialect.NormalizeColumnType(ty); actual != expected {
+						t.Errorf("expected %q to normalize to %q, got %q", ty, expected, actual)
+					}
+				}
+			}
+			for _, types := range c.differ {
+				if sameColumnType(c.dialect, types[0], types[1]) {
+					t.Errorf("expected %q and %q to differ", types[0], types[1])
+				}
+			}
+		})
+	}
+}
+
+func TestNormalizeDefault(t *testing.T) {
+	for name, c := range map[string]struct {
+		dialect tableDialect
+		same    [][]string
+		differ  [][]string
+	}{
+		"pgx": {
+			postgresDialect{},
+			[][]string{
+				{"'x'", "'x'::character varying", "('x'::text)::character varying(10)"},
+				{"-1", "'-1'::integer", "(-1)"},
+				{"now()", "NOW()"},
+				{"'{}'", "'{}'::text[]"},
+			},
+			[][]string{
+				{"'x'", "'y'::text"},
+				{"'X'", "'x'::text"},
+				{"0", "1"},
+			},
+		},
+		"mysql": {
+			mysqlDialect{},
+			[][]string{
+				{"'x'", "x"},
+				{"'it''s'", "it's"},
+				{"CURRENT_TIMESTAMP", "current_timestamp"},
+				{"(rand())", "rand()"},
+			},
+			[][]string{
+				{"'x'", "y"},
+				{"0", "1"},
+			},
+		},
+		"sqlserver": {
+			sqlServerDialect{},
+			[][]string{
+				{"0", "((0))"},
+				{"'x'", "('x')"},
+				{"GETDATE()", "(getdate())"},
+			},
+			[][]string{
+				{"'X'", "('x')"},
+				{"(1) + (2)", "1 + 2"},
+				{"0", "((1))"},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			for _, defaults := range c.same {
+				expected := c.dialect.NormalizeDefault(defaults[0])
+				for _, dflt := range defaults[1:] {
+					if actual := c.dialect.NormalizeDefault(dflt); actual != expected {
+						t.Errorf("expected %q to normalize to %q, got %q", dflt, expected, actual)
+					}
+				}
+			}
+			for _, defaults := range c.differ {
+				if sameDefault(c.dialect, defaults[0], defaults[1]) {
+					t.Errorf("expected %q and %q to differ", defaults[0], defaults[1])
+				}
+			}
+		})
+	}
+}
+
+func TestCreateTableStatements(t *testing.T) {
+	dflt := "'none'"
+	tbl := table{
+		Schema: "app",
+		Name:   "users",
+		Columns: []tableColumn{
+			{Name: "id", Type: "bigint", Nullable: false},
+			{Name: "email", Type: "varchar(255)", Nullable: true, Default: &dflt},
+		},
+		PrimaryKey: []string{"id"},
+		Indexes: []tableIndex{
+			{Name: "users_email", Columns: []string{"email"}, Unique: true},
+		},
+	}
+
+	for name, c := range map[string]struct {
+		dialect  tableDialect
+		expected []string
+	}{
+		"pgx": {
+			postgresDialect{},
+			[]string{
+				"CREATE TABLE \"app\".\"users\" (\n\t\"id\" bigint NOT NULL,\n\t\"email\" varchar(255) NULL DEFAULT 'none',\n\tPRIMARY KEY (\"id\")\n)",
+				`CREATE UNIQUE INDEX "users_email" ON "app"."users" ("email")`,
+			},
+		},
+		"mysql": {
+			mysqlDialect{},
+			[]string{
+				"CREATE TABLE `app`.`users` (\n\t`id` bigint NOT NULL,\n\t`email` varchar(255) NULL DEFAULT 'none',\n\tPRIMARY KEY (`id`)\n)",
+				"CREATE UNIQUE INDEX `users_email` ON `app`.`users` (`email`)",
+			},
+		},
+		"sqlserver": {
+			sqlServerDialect{},
+			[]string{
+				"CREATE TABLE [app].[users] (\n\t[id] bigint NOT NULL,\n\t[email] varchar(255) NULL DEFAULT 'none',\n\tPRIMARY KEY ([id])\n)",
+				"CREATE UNIQUE INDEX [users_email] ON [app].[users] ([email])",
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			actual := createTableStatements(c.dialect, tbl)
+			if diff := cmp.Diff(c.expected, actual); diff != "" {
+				t.Fatalf("unexpected statements (-expected +actual):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAlterTableStatements(t *testing.T) {
+	zero := "0"
+	prior := table{
+		Name: "items",
+		Columns: []tableColumn{
+			{Name: "id", Type: "int", Nullable: false},
+			{Name: "name", Type: "varchar(10)", Nullable: true},
+			{Name: "legacy", Type: "int", Nullable: true, Default: &zero},
+			{Name: "count", Type: "integer", Nullable: true},
+		},
+		PrimaryKey: []string{"id"},
+		Indexes: []tableIndex{
+			{Name: "items_name", Columns: []string{"name"}},
+			{Name: "items_legacy", Columns: []string{"legacy"}},
+		},
+	}
+	planned := table{
+		Name: "items",
+		Columns: []tableColumn{
+			{Name: "id", Type: "int", Nullable: false},
+			{Name: "name", Type: "varchar(20)", Nullable: false},
+			{Name: "count", Type: "int", Nullable: true, Default: &zero},
+			{Name: "created", Type: "date", Nullable: true},
+		},
+		PrimaryKey: []string{"id"},
+		Indexes: []tableIndex{
+			{Name: "items_name", Columns: []string{"name"}, Unique: true},
+		},
+	}
+
+	for name, c := range map[string]struct {
+		dialect  tableDialect
+		expected []string
+	}{
+		"pgx": {
+			postgresDialect{},
+			[]string{
+				`DROP INDEX "items_name"`,
+				`DROP INDEX "items_legacy"`,
+				`ALTER TABLE "items" DROP COLUMN "legacy"`,
+				`ALTER TABLE "items" ALTER COLUMN "name" TYPE varchar(20)`,
+				`ALTER TABLE "items" ALTER COLUMN "name" SET NOT NULL`,
+				`ALTER TABLE "items" ALTER COLUMN "count" SET DEFAULT 0`,
+				`ALTER TABLE "items" ADD COLUMN "created" date NULL`,
+				`CREATE UNIQUE INDEX "items_name" ON "items" ("name")`,
+			},
+		},
+		"mysql": {
+			mysqlDialect{},
+			[]string{
+				"DROP INDEX `items_name` ON `items`",
+				"DROP INDEX `items_legacy` ON `items`",
+				"ALTER TABLE `items` DROP COLUMN `legacy`",
+				"ALTER TABLE `items` MODIFY COLUMN `name` varchar(20) NOT NULL",
+				"ALTER TABLE `items` MODIFY COLUMN `count` int NULL DEFAULT 0",
+				"ALTER TABLE `items` ADD COLUMN `created` date NULL",
+				"CREATE UNIQUE INDEX `items_name` ON `items` (`name`)",
+			},
+		},
+		"sqlserver": {
+			sqlServerDialect{},
+			[]string{
+				"DROP INDEX [items_name] ON [items]",
+				"DROP INDEX [items_legacy] ON [items]",
+				sqlServerDialect{}.dropDefault("", "items", "legacy"),
+				"ALTER TABLE [items] DROP COLUMN [legacy]",
+				"ALTER TABLE [items] ALTER COLUMN [name] varchar(20) NOT NULL",
+				"ALTER TABLE [items] ADD DEFAULT 0 FOR [count]",
+				"ALTER TABLE [items] ADD [created] date NULL",
+				"CREATE UNIQUE INDEX [items_name] ON [items] ([name])",
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			actual := alterTableStatements(c.dialect, prior, planned)
+			if diff := cmp.Diff(c.expected, actual); diff != "" {
+				t.Fatalf("unexpected statements (-expected +actual):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReconcileColumns(t *testing.T) {
+	priorDefault := "'x'"
+	actualDefault := "'x'::character varying"
+	prior := []tableColumn{
+		{Name: "b", Type: "varchar(10)", Nullable: true, Default: &priorDefault},
+		{Name: "a", Type: "int", Nullable: false},
+		{Name: "dropped", Type: "int", Nullable: true},
+	}
+	actual := []tableColumn{
+		{Name: "a", Type: "integer", Nullable: true},
+		{Name: "b", Type: "character varying(10)", Nullable: true, Default: &actualDefault},
+		{Name: "added", Type: "text", Nullable: true},
+	}
+
+	expected := []tableColumn{
+		{Name: "b", Type: "varchar(10)", Nullable: true, Default: &priorDefault},
+		{Name: "a", Type: "int", Nullable: true},
+		{Name: "added", Type: "text", Nullable: true},
+	}
+
+	if diff := cmp.Diff(expected, reconcileColumns(postgresDialect{}, prior, actual)); diff != "" {
+		t.Fatalf("unexpected columns (-expected +actual):\n%s", diff)
+	}
+}
+
+func TestReconcileColumns_defaultChanged(t *testing.T) {
+	priorDefault := "'x'"
+	actualDefault := "'y'::character varying"
+	prior := []tableColumn{{Name: "a", Type: "varchar(10)", Nullable: true, Default: &priorDefault}}
+	actual := []tableColumn{{Name: "a", Type: "character varying(10)", Nullable: true, Default: &actualDefault}}
+
+	expected := []tableColumn{{Name: "a", Type: "varchar(10)", Nullable: true, Default: &actualDefault}}
+
+	if diff := cmp.Diff(expected, reconcileColumns(postgresDialect{}, prior, actual)); diff != "" {
+		t.Fatalf("unexpected columns (-expected +actual):\n%s", diff)
+	}
+}
+
+func TestColumnChangeDiags(t *testing.T) {
+	prior := []tableColumn{
+		{Name: "id", Type: "int"},
+		{Name: "name", Type: "varchar(100)", Nullable: true},
+		{Name: "dropped", Type: "text", Nullable: true},
+	}
+
+	for name, c := range map[string]struct {
+		planned  []tableColumn
+		expected []string
+	}{
+		"unchanged": {
+			planned: []tableColumn{
+				{Name: "id", Type: "integer"},
+				{Name: "name", Type: "character varying(100)", Nullable: false},
+				{Name: "dropped", Type: "text", Nullable: true},
+				{Name: "added", Type: "text", Nullable: true},
+			},
+		},
+		"dropped": {
+			planned: []tableColumn{
+				{Name: "id", Type: "int"},
+				{Name: "name", Type: "varchar(100)", Nullable: true},
+			},
+			expected: []string{`Column "dropped" will be dropped.`},
+		},
+		"type changed": {
+			planned: []tableColumn{
+				{Name: "id", Type: "bigint"},
+				{Name: "name", Type: "varchar(10)", Nullable: true},
+				{Name: "dropped", Type: "text", Nullable: true},
+			},
+			expected: []string{
+				`The type of column "id" will be changed from int to bigint.`,
+				`The type of column "name" will be changed from varchar(100) to varchar(10).`,
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var summaries []string
+			for _, diag := range columnChangeDiags(postgresDialect{}, prior, c.planned) {
+				if diag.Severity != tfprotov6.DiagnosticSeverityWarning {
+					t.Errorf("expected a warning, got %v", diag.Severity)
+				}
+				summaries = append(summaries, diag.Summary)
+			}
+			if diff := cmp.Diff(c.expected, summaries); diff != "" {
+				t.Fatalf("unexpected warnings (-expected +actual):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1 @@
+SELECT {{ .greeting }} AS {{ ident .column }}
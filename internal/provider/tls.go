@@ -0,0 +1,160 @@
+package provider
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// tlsSettings are the TLS options of the provider, the certificates and key
+// can either be PEM contents or paths to PEM files.
+type tlsSettings struct {
+	CACert             string
+	ClientCert         string
+	ClientKey          string
+	ServerName         string
+	InsecureSkipVerify bool
+}
+
+func tlsBlock() *tfprotov6.SchemaNestedBlock {
+	return &tfprotov6.SchemaNestedBlock{
+		TypeName: "tls",
+		Nesting:  tfprotov6.SchemaNestedBlockNestingModeSingle,
+		Block: &tfprotov6.SchemaBlock{
+			Description: "TLS settings for the connection, these are translated to the equivalent settings of each " +
+				"driver. Client certificates are not supported by SQL Server, and this block is not supported for " +
+				"`azuresql`, which is always encrypted.",
+			DescriptionKind: tfprotov6.StringKindMarkdown,
+			Attributes: []*tfprotov6.SchemaAttribute{
+				{
+					Name:            "ca_cert",
+					Optional:        true,
+					Description:     "The CA certificate(s) used to verify the server, either as PEM contents or the path to a PEM file.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.String,
+				},
+				{
+					Name:            "client_cert",
+					Optional:        true,
+					Description:     "The client certificate, either as PEM contents or the path to a PEM file.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.String,
+				},
+				{
+					Name:            "client_key",
+					Optional:        true,
+					Sensitive:       true,
+					Description:     "The private key of the client certificate, either as PEM contents or the path to a PEM file.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.String,
+				},
+				{
+					Name:            "server_name",
+					Optional:        true,
+					Description:     "The name used to verify the server certificate, defaults to the host being connected to.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.String,
+				},
+				{
+					Name:            "insecure_skip_verify",
+					Optional:        true,
+					Description:     "Set this to `true` to skip verification of the server certificate. This should only be used for testing.",
+					DescriptionKind: tfprotov6.StringKindMarkdown,
+					Type:            tftypes.Bool,
+				},
+			},
+		},
+	}
+}
+
+func tlsFromValue(v tftypes.Value) (tlsSettings, error) {
+	var s tlsSettings
+
+	var values map[string]tftypes.Value
+	err := v.As(&values)
+	if err != nil {
+		return s, err
+	}
+
+	for name, target := range map[string]*string{
+		"ca_cert":     &s.CACert,
+		"client_cert": &s.ClientCert,
+		"client_key":  &s.ClientKey,
+		"server_name": &s.ServerName,
+	} {
+		err = values[name].As(target)
+		if err != nil {
+			return s, attributeErrorf(name, "unable to read %s: %w", name, err)
+		}
+	}
+
+	err = values["insecure_skip_verify"].As(&s.InsecureSkipVerify)
+	if err != nil {
+		return s, attributeErrorf("insecure_skip_verify", "unable to read insecure_skip_verify: %w", err)
+	}
+
+	return s, nil
+}
+
+// Config builds the tls.Config for the settings, the server name is left empty
+// if not set so that each driver can default it to the host.
+func (s tlsSettings) Config() (*tls.Config, error) {
+	cfg := &tls.Config{
+		ServerName:         s.ServerName,
+		InsecureSkipVerify: s.InsecureSkipVerify,
+	}
+
+	if s.CACert != "" {
+		pem, err := readPEM(s.CACert)
+		if err != nil {
+			return nil, attributeErrorf("ca_cert", "unable to read ca_cert: %w", err)
+		}
+
+		cfg.RootCAs = x509.NewCertPool()
+		if !cfg.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, attributeErrorf("ca_cert", "ca_cert does not contain any PEM certificates")
+		}
+	}
+
+	if s.ClientCert != "" || s.ClientKey != "" {
+		if s.ClientCert == "" {
+			return nil, attributeErrorf("client_cert", "client_cert and client_key must be set together")
+		}
+		if s.ClientKey == "" {
+			return nil, attributeErrorf("client_key", "client_cert and client_key must be set together")
+		}
+
+		certPEM, err := readPEM(s.ClientCert)
+		if err != nil {
+			return nil, attributeErrorf("client_cert", "unable to read client_cert: %w", err)
+		}
+
+		keyPEM, err := readPEM(s.ClientKey)
+		if err != nil {
+			return nil, attributeErrorf("client_key", "unable to read client_key: %w", err)
+		}
+
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return nil, attributeErrorf("client_cert", "unable to load client certificate: %w", err)
+		}
+
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return cfg, nil
+}
+
+// readPEM returns the value if it is PEM contents, otherwise it is treated as
+// the path of a PEM file.
+func readPEM(v string) ([]byte, error) {
+	if strings.Contains(v, "-----BEGIN") {
+		return []byte(v), nil
+	}
+
+	return os.ReadFile(v)
+}
@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func testCertificatePEM(t *testing.T) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "terraform-provider-sql test"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+
+	return string(certPEM), string(keyPEM)
+}
+
+func TestTLSSettings_Config(t *testing.T) {
+	certPEM, keyPEM := testCertificatePEM(t)
+
+	certPath := filepath.Join(t.TempDir(), "cert.pem")
+	err := os.WriteFile(certPath, []byte(certPEM), 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, s := range map[string]tlsSettings{
+		"contents": {
+			CACert:     certPEM,
+			ClientCert: certPEM,
+			ClientKey:  keyPEM,
+			ServerName: "db.example.com",
+		},
+		"paths": {
+			CACert:     certPath,
+			ClientCert: certPath,
+			ClientKey:  keyPEM,
+			ServerName: "db.example.com",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			cfg, err := s.Config()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if cfg.RootCAs == nil {
+				t.Fatalf("expected root CAs to be set")
+			}
+
+			if len(cfg.Certificates) != 1 {
+				t.Fatalf("expected one client certificate, got %d", len(cfg.Certificates))
+			}
+
+			if cfg.ServerName != "db.example.com" {
+				t.Fatalf("expected server name to be set, got %q", cfg.ServerName)
+			}
+		})
+	}
+}
+
+func TestTLSSettings_Config_invalid(t *testing.T) {
+	certPEM, _ := testCertificatePEM(t)
+
+	for name, s := range map[string]tlsSettings{
+		"missing file": {CACert: filepath.Join(t.TempDir(), "does-not-exist.pem")},
+		"no certs":     {CACert: "-----BEGIN NOTHING-----"},
+		"missing key":  {ClientCert: certPEM},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := s.Config()
+			if err == nil {
+				t.Fatalf("expected error but got none")
+			}
+		})
+	}
+}
@@ -0,0 +1,314 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// attributeError is an error caused by a single attribute of a nested block,
+// so that its diagnostic can point at the attribute instead of the block.
+type attributeError struct {
+	name string
+	err  error
+}
+
+func attributeErrorf(name string, format string, args ...interface{}) error {
+	return &attributeError{
+		name: name,
+		err:  fmt.Errorf(format, args...),
+	}
+}
+
+func (e *attributeError) Error() string {
+	return e.err.Error()
+}
+
+func (e *attributeError) Unwrap() error {
+	return e.err
+}
+
+func errorDiag(summary string, steps ...tftypes.AttributePathStep) *tfprotov6.Diagnostic {
+	return &tfprotov6.Diagnostic{
+		Severity:  tfprotov6.DiagnosticSeverityError,
+		Summary:   summary,
+		Attribute: tftypes.NewAttributePathWithSteps(steps),
+	}
+}
+
+// blockDiags returns the diagnostic for an error reading a nested block, at the
+// attribute that caused it if known.
+func blockDiags(block string, summary string, err error) []*tfprotov6.Diagnostic {
+	steps := []tftypes.AttributePathStep{tftypes.AttributeName(block)}
+
+	var attrErr *attributeError
+	if errors.As(err, &attrErr) {
+		steps = append(steps, tftypes.AttributeName(attrErr.name))
+	}
+
+	return []*tfprotov6.Diagnostic{
+		errorDiag(fmt.Sprintf("%s: %s.", summary, err), steps...),
+	}
+}
+
+// attributeDiags returns the diagnostic for an error reading a top level
+// attribute, at the attribute that caused it if known.
+func attributeDiags(name string, err error) []*tfprotov6.Diagnostic {
+	var attrErr *attributeError
+	if errors.As(err, &attrErr) {
+		name = attrErr.name
+	}
+
+	return []*tfprotov6.Diagnostic{
+		errorDiag(fmt.Sprintf("Invalid %s: %s.", name, err), tftypes.AttributeName(name)),
+	}
+}
+
+func (p *provider) Validate(ctx context.Context, config map[string]tftypes.Value) ([]*tfprotov6.Diagnostic, error) {
+	var diags []*tfprotov6.Diagnostic
+
+	diags = append(diags, validateURL(config)...)
+	diags = append(diags, validateConnection(config)...)
+	for _, name := range []string{"max_open_conns", "max_idle_conns", "max_result_bytes"} {
+		diags = append(diags, validateNonNegativeInt(config, name)...)
+	}
+	for _, name := range []string{"conn_max_lifetime", "conn_max_idle_time"} {
+		diags = append(diags, validateDuration(config, name)...)
+	}
+	diags = append(diags, validatePassword(config)...)
+	diags = append(diags, validateAWSIAMAuth(config)...)
+	diags = append(diags, validateTLS(config)...)
+	diags = append(diags, validateSSHTunnel(config)...)
+	diags = append(diags, validateConnectRetry(config)...)
+
+	return diags, nil
+}
+
+func validateURL(config map[string]tftypes.Value) []*tfprotov6.Diagnostic {
+	v := config["url"]
+	if v.IsNull() || !v.IsKnown() {
+		return nil
+	}
+
+	var url string
+	err := v.As(&url)
+	if err == nil {
+		_, err = parseUrl(url)
+	}
+	if err != nil {
+		return []*tfprotov6.Diagnostic{
+			errorDiag(fmt.Sprintf("Invalid url: %s.", err), tftypes.AttributeName("url")),
+		}
+	}
+
+	return nil
+}
+
+func validateConnection(config map[string]tftypes.Value) []*tfprotov6.Diagnostic {
+	connection := config["connection"]
+	if connection.IsNull() {
+		return nil
+	}
+
+	if url := config["url"]; !url.IsNull() {
+		return []*tfprotov6.Diagnostic{
+			errorDiag("Only one of url or connection can be set.", tftypes.AttributeName("connection")),
+		}
+	}
+
+	if !connection.IsFullyKnown() {
+		return nil
+	}
+
+	c, err := connectionFromValue(connection)
+	if err == nil {
+		_, err = c.URL()
+	}
+	if err != nil {
+		return blockDiags("connection", "Invalid connection", err)
+	}
+
+	return nil
+}
+
+func validateNonNegativeInt(config map[string]tftypes.Value, name string) []*tfprotov6.Diagnostic {
+	v := config[name]
+	if v.IsNull() || !v.IsKnown() {
+		return nil
+	}
+
+	i, err := int64FromValue(v)
+	if err != nil || i < 0 {
+		return []*tfprotov6.Diagnostic{
+			errorDiag(fmt.Sprintf("Invalid %s: must be a non-negative integer.", name), tftypes.AttributeName(name)),
+		}
+	}
+
+	return nil
+}
+
+func validateDuration(config map[string]tftypes.Value, name string) []*tfprotov6.Diagnostic {
+	v := config[name]
+	if v.IsNull() || !v.IsKnown() {
+		return nil
+	}
+
+	_, err := durationFromValue(v)
+	if err != nil {
+		return []*tfprotov6.Diagnostic{
+			errorDiag(fmt.Sprintf("Invalid %s: %s.", name, err), tftypes.AttributeName(name)),
+		}
+	}
+
+	return nil
+}
+
+func validatePassword(config map[string]tftypes.Value) []*tfprotov6.Diagnostic {
+	if !config["password_command"].IsFullyKnown() || !config["password_file"].IsFullyKnown() {
+		return nil
+	}
+
+	_, err := passwordFromConfig(config)
+
+	var attrErr *attributeError
+	if errors.As(err, &attrErr) {
+		return []*tfprotov6.Diagnostic{
+			errorDiag(fmt.Sprintf("Invalid %s: %s.", attrErr.name, err), tftypes.AttributeName(attrErr.name)),
+		}
+	}
+
+	return nil
+}
+
+func validateAWSIAMAuth(config map[string]tftypes.Value) []*tfprotov6.Diagnostic {
+	v := config["aws_iam_auth"]
+	if v.IsNull() || !v.IsKnown() {
+		return nil
+	}
+
+	var enabled bool
+	if v.As(&enabled) != nil || !enabled {
+		return nil
+	}
+
+	if !config["password_command"].IsNull() || !config["password_file"].IsNull() {
+		return []*tfprotov6.Diagnostic{
+			errorDiag("aws_iam_auth can't be used with password_command or password_file.", tftypes.AttributeName("aws_iam_auth")),
+		}
+	}
+
+	switch driver := configuredDriver(config); driver {
+	case "", driverPgx, driverMySQL:
+	default:
+		return []*tfprotov6.Diagnostic{
+			errorDiag(fmt.Sprintf("aws_iam_auth is only supported for postgres and mysql, not %s.", driver),
+				tftypes.AttributeName("aws_iam_auth")),
+		}
+	}
+
+	return nil
+}
+
+func validateTLS(config map[string]tftypes.Value) []*tfprotov6.Diagnostic {
+	v := config["tls"]
+	if v.IsNull() || !v.IsFullyKnown() {
+		return nil
+	}
+
+	s, err := tlsFromValue(v)
+	if err == nil {
+		_, err = s.Config()
+	}
+	if err != nil {
+		return blockDiags("tls", "Invalid TLS settings", err)
+	}
+
+	// these are also rejected when connecting, but can be caught earlier if
+	// the driver is already known
+	switch configuredDriver(config) {
+	case driverAzureSQL:
+		return []*tfprotov6.Diagnostic{
+			errorDiag("TLS settings are not supported for azuresql, connections are always encrypted.",
+				tftypes.AttributeName("tls")),
+		}
+	case driverSQLServer:
+		if s.ClientCert != "" {
+			return []*tfprotov6.Diagnostic{
+				errorDiag("Client certificates are not supported by SQL Server.",
+					tftypes.AttributeName("tls"), tftypes.AttributeName("client_cert")),
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateSSHTunnel(config map[string]tftypes.Value) []*tfprotov6.Diagnostic {
+	v := config["ssh_tunnel"]
+	if v.IsNull() || !v.IsFullyKnown() {
+		return nil
+	}
+
+	s, err := sshTunnelFromValue(v)
+	if err == nil {
+		_, err = s.Tunnel()
+	}
+	if err != nil {
+		return blockDiags("ssh_tunnel", "Invalid SSH tunnel settings", err)
+	}
+
+	return nil
+}
+
+func validateConnectRetry(config map[string]tftypes.Value) []*tfprotov6.Diagnostic {
+	v := config["connect_retry"]
+	if v.IsNull() || !v.IsFullyKnown() {
+		return nil
+	}
+
+	_, err := retryFromValue(v)
+	if err != nil {
+		return blockDiags("connect_retry", "Invalid connect_retry settings", err)
+	}
+
+	return nil
+}
+
+// configuredDriver returns the driver of the url or connection in the config,
+// or an empty string if it is not known yet.
+func configuredDriver(config map[string]tftypes.Value) driverName {
+	if url := config["url"]; url.IsKnown() && !url.IsNull() {
+		var s string
+		if url.As(&s) != nil {
+			return ""
+		}
+
+		ds, err := parseUrl(s)
+		if err != nil {
+			return ""
+		}
+		return ds.driver
+	}
+
+	if connection := config["connection"]; connection.IsKnown() && !connection.IsNull() {
+		var values map[string]tftypes.Value
+		if connection.As(&values) != nil {
+			return ""
+		}
+
+		var driver string
+		if values["driver"].As(&driver) != nil {
+			return ""
+		}
+
+		if d := dialectForScheme(driver); d != nil {
+			return d.Driver()
+		}
+		return ""
+	}
+
+	return ""
+}
@@ -0,0 +1,167 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestProvider_Validate(t *testing.T) {
+	p := &provider{}
+	ty := p.Schema(context.Background()).ValueType().(tftypes.Object)
+
+	block := func(name string, attrs map[string]tftypes.Value) tftypes.Value {
+		blockTy := ty.AttributeTypes[name].(tftypes.Object)
+
+		values := map[string]tftypes.Value{}
+		for name, attrTy := range blockTy.AttributeTypes {
+			values[name] = tftypes.NewValue(attrTy, nil)
+		}
+		for name, v := range attrs {
+			values[name] = v
+		}
+		return tftypes.NewValue(blockTy, values)
+	}
+
+	str := func(s string) tftypes.Value {
+		return tftypes.NewValue(tftypes.String, s)
+	}
+
+	for name, c := range map[string]struct {
+		attrs    map[string]tftypes.Value
+		expected *tftypes.AttributePath
+	}{
+		"valid": {
+			map[string]tftypes.Value{
+				"url":            str("postgres://localhost/db"),
+				"max_open_conns": tftypes.NewValue(tftypes.Number, 10),
+			},
+			nil,
+		},
+		"unknown url": {
+			map[string]tftypes.Value{
+				"url": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			},
+			nil,
+		},
+		"unsupported scheme": {
+			map[string]tftypes.Value{
+				"url": str("oracle://localhost/db"),
+			},
+			tftypes.NewAttributePath().WithAttributeName("url"),
+		},
+		"negative pool size": {
+			map[string]tftypes.Value{
+				"max_idle_conns": tftypes.NewValue(tftypes.Number, -1),
+			},
+			tftypes.NewAttributePath().WithAttributeName("max_idle_conns"),
+		},
+		"fractional pool size": {
+			map[string]tftypes.Value{
+				"max_open_conns": tftypes.NewValue(tftypes.Number, 1.5),
+			},
+			tftypes.NewAttributePath().WithAttributeName("max_open_conns"),
+		},
+		"url and connection": {
+			map[string]tftypes.Value{
+				"url": str("postgres://localhost/db"),
+				"connection": block("connection", map[string]tftypes.Value{
+					"driver": str("postgres"),
+					"host":   str("localhost"),
+				}),
+			},
+			tftypes.NewAttributePath().WithAttributeName("connection"),
+		},
+		"connection driver": {
+			map[string]tftypes.Value{
+				"connection": block("connection", map[string]tftypes.Value{
+					"driver": str("oracle"),
+					"host":   str("localhost"),
+				}),
+			},
+			tftypes.NewAttributePath().WithAttributeName("connection").WithAttributeName("driver"),
+		},
+		"connection port": {
+			map[string]tftypes.Value{
+				"connection": block("connection", map[string]tftypes.Value{
+					"driver": str("postgres"),
+					"host":   str("localhost"),
+					"port":   tftypes.NewValue(tftypes.Number, 70000),
+				}),
+			},
+			tftypes.NewAttributePath().WithAttributeName("connection").WithAttributeName("port"),
+		},
+		"connection password without username": {
+			map[string]tftypes.Value{
+				"connection": block("connection", map[string]tftypes.Value{
+					"driver":   str("postgres"),
+					"host":     str("localhost"),
+					"password": str("secret"),
+				}),
+			},
+			tftypes.NewAttributePath().WithAttributeName("connection").WithAttributeName("password"),
+		},
+		"tls client key without cert": {
+			map[string]tftypes.Value{
+				"tls": block("tls", map[string]tftypes.Value{
+					"client_key": str("key.pem"),
+				}),
+			},
+			tftypes.NewAttributePath().WithAttributeName("tls").WithAttributeName("client_cert"),
+		},
+		"tls with azuresql": {
+			map[string]tftypes.Value{
+				"url": str("azuresql://server.database.windows.net?database=db&fedauth=ActiveDirectoryDefault"),
+				"tls": block("tls", map[string]tftypes.Value{
+					"insecure_skip_verify": tftypes.NewValue(tftypes.Bool, true),
+				}),
+			},
+			tftypes.NewAttributePath().WithAttributeName("tls"),
+		},
+		"aws_iam_auth with sqlserver": {
+			map[string]tftypes.Value{
+				"url":          str("sqlserver://sa@localhost:1433?database=db"),
+				"aws_iam_auth": tftypes.NewValue(tftypes.Bool, true),
+			},
+			tftypes.NewAttributePath().WithAttributeName("aws_iam_auth"),
+		},
+		"aws_iam_auth with password_file": {
+			map[string]tftypes.Value{
+				"url":           str("postgres://iam_user@db.abc123.us-east-1.rds.amazonaws.com/db"),
+				"aws_iam_auth":  tftypes.NewValue(tftypes.Bool, true),
+				"password_file": str("/run/secrets/db"),
+			},
+			tftypes.NewAttributePath().WithAttributeName("aws_iam_auth"),
+		},
+		"connect_retry interval": {
+			map[string]tftypes.Value{
+				"connect_retry": block("connect_retry", map[string]tftypes.Value{
+					"initial_interval": str("soon"),
+				}),
+			},
+			tftypes.NewAttributePath().WithAttributeName("connect_retry").WithAttributeName("initial_interval"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			diags, err := p.Validate(context.Background(), testProviderConfig(p, c.attrs))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if c.expected == nil {
+				if len(diags) > 0 {
+					t.Fatalf("unexpected diag: %s", diags[0].Summary)
+				}
+				return
+			}
+
+			if len(diags) != 1 {
+				t.Fatalf("expected a single diag, got %d", len(diags))
+			}
+			if !diags[0].Attribute.Equal(c.expected) {
+				t.Fatalf("expected diag on %s, got %s: %s", c.expected, diags[0].Attribute, diags[0].Summary)
+			}
+		})
+	}
+}
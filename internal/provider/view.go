@@ -0,0 +1,101 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// view is a view and the query defining it.
+type view struct {
+	Schema     string
+	Name       string
+	Definition string
+}
+
+// viewDialect is implemented by the dialects that support managing views.
+type viewDialect interface {
+	Dialect
+
+	// ReadView returns the definition of the view as stored by the database,
+	// which may be normalised, or nil if the view does not exist.
+	ReadView(ctx context.Context, db dbQueryer, schema, name string) (*string, error)
+
+	// CreateView creates the view, or replaces its definition.
+	CreateView(v view) []string
+	DropView(schema, name string) string
+}
+
+// function is a stored function. Arguments and Returns are as written in the
+// function signature, and Body is the remainder of the definition.
+type function struct {
+	Schema    string
+	Name      string
+	Arguments string
+	Returns   string
+	Language  *string
+	Body      string
+}
+
+// functionDialect is implemented by the dialects that support managing
+// functions.
+type functionDialect interface {
+	Dialect
+
+	// ReadFunction returns the definition of the function with the name and
+	// arguments as stored by the database, or nil if the function does not
+	// exist.
+	ReadFunction(ctx context.Context, db dbQueryer, f function) (*string, error)
+
+	// CreateFunction creates the function, or replaces its definition.
+	CreateFunction(f function) ([]string, error)
+	DropFunction(f function) string
+}
+
+// dollarQuote quotes the string with a PostgreSQL dollar quote tag that it
+// does not contain.
+func dollarQuote(s string) string {
+	tag := "$function$"
+	for i := 1; strings.Contains(s, tag); i++ {
+		tag = fmt.Sprintf("$function%d$", i)
+	}
+	return tag + s + tag
+}
+
+// readDefinition returns the single definition selected by the query, or nil
+// if there is none. Overloaded functions are ambiguous, the first is used.
+func readDefinition(ctx context.Context, db dbQueryer, query string, args ...interface{}) (*string, error) {
+	definitions, err := queryStrings(ctx, db, query, args...)
+	if err != nil || len(definitions) == 0 {
+		return nil, err
+	}
+	return &definitions[0], nil
+}
+
+// splitArguments splits a list of function arguments on the commas that are
+// not within parentheses or quotes.
+func splitArguments(arguments string) []string {
+	var args []string
+	depth, quote, start := 0, rune(0), 0
+	for i, r := range arguments {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"':
+			quote = r
+		case r == '(':
+			depth++
+		case r == ')':
+			depth--
+		case r == ',' && depth == 0:
+			args = append(args, strings.TrimSpace(arguments[start:i]))
+			start = i + 1
+		}
+	}
+	if last := strings.TrimSpace(arguments[start:]); last != "" {
+		args = append(args, last)
+	}
+	return args
+}
@@ -0,0 +1,162 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestDollarQuote(t *testing.T) {
+	for name, c := range map[string]struct {
+		body     string
+		expected string
+	}{
+		"plain":    {"SELECT 1", "$function$SELECT 1$function$"},
+		"contains": {"SELECT '$function$'", "$function1$SELECT '$function$'$function1$"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			actual := dollarQuote(c.body)
+			if actual != c.expected {
+				t.Fatalf("expected %s, got %s", c.expected, actual)
+			}
+		})
+	}
+}
+
+func TestCreateFunction(t *testing.T) {
+	plpgsql := "plpgsql"
+
+	for name, c := range map[string]struct {
+		dialect  functionDialect
+		function function
+		expected []string
+	}{
+		"pgx": {
+			postgresDialect{},
+			function{Name: "add", Arguments: "a integer, b integer", Returns: "integer", Body: "SELECT a + b"},
+			[]string{`CREATE OR REPLACE FUNCTION "add"(a integer, b integer) RETURNS integer LANGUAGE sql AS $function$SELECT a + b$function$`},
+		},
+		"pgx language": {
+			postgresDialect{},
+			function{Schema: "app", Name: "one", Returns: "integer", Language: &plpgsql, Body: "BEGIN RETURN 1; END"},
+			[]string{`CREATE OR REPLACE FUNCTION "app"."one"() RETURNS integer LANGUAGE plpgsql AS $function$BEGIN RETURN 1; END$function$`},
+		},
+		"mysql": {
+			mysqlDialect{},
+			function{Name: "add", Arguments: "a INT, b INT", Returns: "INT", Body: "DETERMINISTIC RETURN a + b"},
+			[]string{
+				"DROP FUNCTION IF EXISTS `add`",
+				"CREATE FUNCTION `add`(a INT, b INT) RETURNS INT DETERMINISTIC RETURN a + b",
+			},
+		},
+		"sqlserver": {
+			sqlServerDialect{},
+			function{Schema: "dbo", Name: "add", Arguments: "@a int, @b int", Returns: "int", Body: "BEGIN RETURN @a + @b END"},
+			[]string{"CREATE OR ALTER FUNCTION [dbo].[add](@a int, @b int) RETURNS int AS BEGIN RETURN @a + @b END"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			actual, err := c.dialect.CreateFunction(c.function)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(c.expected, actual); diff != "" {
+				t.Fatalf("unexpected statements (-expected +actual):\n%s", diff)
+			}
+		})
+	}
+
+	for name, d := range map[string]functionDialect{
+		"mysql":     mysqlDialect{},
+		"sqlserver": sqlServerDialect{},
+	} {
+		t.Run(name+" language", func(t *testing.T) {
+			_, err := d.CreateFunction(function{Name: "one", Returns: "int", Language: &plpgsql, Body: "RETURN 1"})
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
+
+func TestPlanDefinition(t *testing.T) {
+	str := func(s string) tftypes.Value { return tftypes.NewValue(tftypes.String, s) }
+	prior := map[string]tftypes.Value{
+		"schema":                tftypes.NewValue(tftypes.String, nil),
+		"name":                  str("active_users"),
+		"definition":            str("SELECT * FROM users WHERE active"),
+		"normalized_definition": str(" SELECT users.id\n   FROM users\n  WHERE users.active;"),
+		"id":                    str("active_users"),
+	}
+
+	for name, c := range map[string]struct {
+		definition string
+		unknown    bool
+	}{
+		"unchanged": {"SELECT * FROM users WHERE active", false},
+		"changed":   {"SELECT * FROM users WHERE NOT active", true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			proposed := map[string]tftypes.Value{}
+			for k, v := range prior {
+				proposed[k] = v
+			}
+			proposed["definition"] = str(c.definition)
+
+			planned, err := planDefinition(proposed, proposed, prior, "definition")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if planned["normalized_definition"].IsKnown() == c.unknown {
+				t.Fatalf("expected unknown to be %t, got %s", c.unknown, planned["normalized_definition"])
+			}
+			if !planned["id"].Equal(prior["id"]) {
+				t.Fatalf("unexpected id %s", planned["id"])
+			}
+		})
+	}
+}
+
+func TestReadDefinitionState(t *testing.T) {
+	str := func(s string) tftypes.Value { return tftypes.NewValue(tftypes.String, s) }
+	current := map[string]tftypes.Value{
+		"definition":            str("SELECT 1"),
+		"normalized_definition": str(" SELECT 1;"),
+	}
+
+	state, _, err := readDefinitionState(current, " SELECT 1;", "definition")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !state["definition"].Equal(str("SELECT 1")) {
+		t.Fatalf("expected the configured definition to be kept, got %s", state["definition"])
+	}
+
+	state, _, err = readDefinitionState(current, " SELECT 2;", "definition")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !state["definition"].Equal(str(" SELECT 2;")) || !state["normalized_definition"].Equal(str(" SELECT 2;")) {
+		t.Fatalf("expected the drifted definition to be read, got %s", state["definition"])
+	}
+}
+
+func TestPostgresArgumentTypes(t *testing.T) {
+	for arguments, expected := range map[string]string{
+		"":                                     "",
+		"integer":                              "integer",
+		"a integer, b integer":                 "integer, integer",
+		"a numeric(10, 2), b int[]":            "numeric(10, 2), int[]",
+		"double precision, x double precision": "double precision, double precision",
+		"ts timestamp with time zone":          "timestamp with time zone",
+		"IN a text, OUT b text, INOUT c int":   "text, int",
+		"VARIADIC vals int[]":                  "int[]",
+		`"my arg" text DEFAULT 'a, b'`:         "text",
+		"a int = 1, b character varying(10)":   "int, character varying(10)",
+	} {
+		if actual := postgresArgumentTypes(arguments); actual != expected {
+			t.Errorf("expected %q to have the types %q, got %q", arguments, expected, actual)
+		}
+	}
+}
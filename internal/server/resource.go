@@ -7,6 +7,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
 )
 
+// Resource is a managed resource. Read returns a nil state when the resource
+// no longer exists, so that it is planned to be created again.
 type Resource interface {
 	Schema(ctx context.Context) *tfprotov6.Schema
 	Validate(ctx context.Context, config map[string]tftypes.Value) (diags []*tfprotov6.Diagnostic, err error)
@@ -20,3 +22,18 @@ type ResourceUpdater interface {
 	PlanUpdate(ctx context.Context, proposed map[string]tftypes.Value, config map[string]tftypes.Value, prior map[string]tftypes.Value) (planned map[string]tftypes.Value, diags []*tfprotov6.Diagnostic, err error)
 	Update(ctx context.Context, planned map[string]tftypes.Value, config map[string]tftypes.Value, prior map[string]tftypes.Value) (state map[string]tftypes.Value, diags []*tfprotov6.Diagnostic, err error)
 }
+
+// ResourceReplacer is implemented by resources with changes that can't be
+// applied in place. RequiresReplace is called when planning an update and
+// returns the paths of the attributes that force the resource to be destroyed
+// and created again.
+type ResourceReplacer interface {
+	RequiresReplace(ctx context.Context, planned map[string]tftypes.Value, prior map[string]tftypes.Value) (paths []*tftypes.AttributePath, err error)
+}
+
+// ResourceImporter is implemented by resources that can be imported. Import
+// returns the state identified by id, attributes it does not set are null. The
+// state is then read as usual.
+type ResourceImporter interface {
+	Import(ctx context.Context, id string) (state map[string]tftypes.Value, diags []*tfprotov6.Diagnostic, err error)
+}
@@ -333,6 +333,19 @@ func (s *Server) ReadResource(ctx context.Context, req *tfprotov6.ReadResourceRe
 		}, nil
 	}
 
+	if newState == nil {
+		// the resource was removed outside of Terraform
+		newStateValue, err := tfprotov6.NewDynamicValue(schemaObjectType, tftypes.NewValue(schemaObjectType, nil))
+		if err != nil {
+			return nil, fmt.Errorf("ReadResource - error NewDynamicValue: %w", err)
+		}
+
+		return &tfprotov6.ReadResourceResponse{
+			NewState:    &newStateValue,
+			Diagnostics: diags,
+		}, nil
+	}
+
 	newStateValue, err := tfprotov6.NewDynamicValue(schemaObjectType, tftypes.NewValue(schemaObjectType, newState))
 	if err != nil {
 		return nil, fmt.Errorf("ApplyResourceChange - error NewDynamicValue: %w", err)
@@ -386,6 +399,7 @@ func (s *Server) PlanResourceChange(ctx context.Context, req *tfprotov6.PlanReso
 	}
 
 	var planned map[string]tftypes.Value
+	var requiresReplace []*tftypes.AttributePath
 	if priorObject.IsNull() {
 		var createDiags []*tfprotov6.Diagnostic
 		planned, createDiags, err = r.PlanCreate(ctx, proposed, config)
@@ -404,6 +418,13 @@ func (s *Server) PlanResourceChange(ctx context.Context, req *tfprotov6.PlanReso
 			return nil, err
 		}
 		diags = append(diags, updateDiags...)
+
+		if replacer, ok := r.(ResourceReplacer); ok && !diagsHaveError(diags) {
+			requiresReplace, err = replacer.RequiresReplace(ctx, planned, prior)
+			if err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	if diagsHaveError(diags) {
@@ -418,8 +439,9 @@ func (s *Server) PlanResourceChange(ctx context.Context, req *tfprotov6.PlanReso
 	}
 
 	return &tfprotov6.PlanResourceChangeResponse{
-		PlannedState: &plannedValue,
-		Diagnostics:  diags,
+		PlannedState:    &plannedValue,
+		RequiresReplace: requiresReplace,
+		Diagnostics:     diags,
 	}, nil
 }
 
@@ -516,7 +538,57 @@ func (s *Server) ApplyResourceChange(ctx context.Context, req *tfprotov6.ApplyRe
 }
 
 func (s *Server) ImportResourceState(ctx context.Context, req *tfprotov6.ImportResourceStateRequest) (*tfprotov6.ImportResourceStateResponse, error) {
-	panic("not implemented")
+	r, err := s.resource(TypeName(req.TypeName))
+	if err != nil {
+		return nil, err
+	}
+
+	importer, ok := r.(ResourceImporter)
+	if !ok {
+		return &tfprotov6.ImportResourceStateResponse{
+			Diagnostics: []*tfprotov6.Diagnostic{
+				{
+					Severity: tfprotov6.DiagnosticSeverityError,
+					Summary:  "Resource Import Not Implemented",
+					Detail:   fmt.Sprintf("The %s resource does not support import.", req.TypeName),
+				},
+			},
+		}, nil
+	}
+
+	schemaObjectType := schemaAsObject(r.Schema(ctx))
+
+	state, diags, err := importer.Import(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if diagsHaveError(diags) {
+		return &tfprotov6.ImportResourceStateResponse{
+			Diagnostics: diags,
+		}, nil
+	}
+
+	for name, ty := range schemaObjectType.AttributeTypes {
+		if _, ok := state[name]; !ok {
+			state[name] = tftypes.NewValue(ty, nil)
+		}
+	}
+
+	stateValue, err := tfprotov6.NewDynamicValue(schemaObjectType, tftypes.NewValue(schemaObjectType, state))
+	if err != nil {
+		return nil, fmt.Errorf("ImportResourceState - error NewDynamicValue: %w", err)
+	}
+
+	return &tfprotov6.ImportResourceStateResponse{
+		ImportedResources: []*tfprotov6.ImportedResource{
+			{
+				TypeName: req.TypeName,
+				State:    &stateValue,
+			},
+		},
+		Diagnostics: diags,
+	}, nil
 }
 
 // DataSourceServer methods